@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/casbin/casbin/v3"
+	"github.com/casbin/casbin/v3/persist"
 	"github.com/gin-gonic/gin"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/panjf2000/ants/v2"
@@ -24,11 +28,19 @@ import (
 	"gorm.io/gorm"
 )
 
-// Version 版本号
-const Version string = "1.0.0"
+// Version 版本号，可在编译时通过 -ldflags "-X github.com/otzgo/abe.Version=x.y.z" 覆盖，见 BuildInfo
+var Version = "1.0.0"
 
 const defaultShutdownTimeout = 5 * time.Second
 
+// HTTP 服务器默认超时，防御慢速连接（slowloris）耗尽连接资源
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultReadTimeout       = 60 * time.Second
+	defaultWriteTimeout      = 60 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+)
+
 // Engine 应用引擎
 type Engine struct {
 	config            *viper.Viper
@@ -38,10 +50,13 @@ type Engine struct {
 	events            EventBus
 	pool              *ants.Pool
 	logger            *slog.Logger
+	logLevel          *slog.LevelVar
 	enforcer          *casbin.Enforcer
+	policyWatcher     persist.Watcher
 	validator         *Validator
 	middlewareManager *MiddlewareManager
 	i18nBundle        *i18n.Bundle
+	i18nReloaded      atomic.Pointer[i18n.Bundle] // ReloadI18n 加载的消息包，非 nil 时替代 i18nBundle
 
 	/* RunOption */
 	basePath string // 路由基础路径
@@ -53,8 +68,52 @@ type Engine struct {
 	controllersMu      sync.RWMutex
 	controllerRegistry []ControllerProvider
 
-	httpServer *http.Server
-	plugins    *PluginManager
+	httpServer     *http.Server
+	redirectServer *http.Server // HTTP 跳转 HTTPS 的明文监听，未配置 server.tls.redirect_http_port 时为 nil
+	plugins        *PluginManager
+
+	deadLettersOnce sync.Once
+	deadLetters     DeadLetterStore
+
+	asyncJobsOnce sync.Once
+	asyncJobs     AsyncJobStore
+
+	routePermissionsMu sync.RWMutex
+	routePermissions   []RoutePermission
+
+	routeSchemasMu sync.RWMutex
+	routeSchemas   []RouteSchema
+
+	cronJobsMu sync.Mutex
+	cronJobs   map[string]cronJob // 任务名 -> 具名定时任务
+
+	cronLockerOnce sync.Once
+	cronLocker     CronLocker
+
+	clock Clock
+
+	locationOnce sync.Once
+	location     *time.Location // 业务时区，见 Location
+
+	authnRegistered atomic.Bool // 是否注册过认证中间件，用于校验 auth.jwt_secret
+	authzRegistered atomic.Bool // 是否注册过授权中间件，用于启动时检查权限控制器
+
+	healthMu      sync.RWMutex
+	healthOptions *HealthOptions // 健康检查端点配置，nil 表示未启用
+	healthChecks  []HealthCheck
+
+	staticMu     sync.Mutex
+	staticMounts []staticMount // 静态文件/SPA 挂载，见 ServeStatic、ServeSPA
+
+	providersMu      sync.Mutex
+	requestProviders []func(ctx *gin.Context, scope do.Injector) // 请求级容器的额外注册，见 ProvideUseCase、ProvideRequest
+
+	shutdownMu    sync.Mutex
+	shutdownHooks []ShutdownFunc // 停机排空回调，见 OnShutdown
+
+	configMu        sync.Mutex
+	configListeners []func(key string)
+	configSnapshot  map[string]any // 最近一次加载的扁平化配置，用于计算变更键
 }
 
 // Injector 依赖注入器
@@ -69,14 +128,15 @@ func (e *Engine) Run(opts ...RunOption) {
 	}
 
 	e.doPackage()
-
-	e.Plugins().onBeforeMount()
-	e.mountControllers(e.basePath)
-	e.Plugins().onAfterMount()
-	e.initializeHTTPServer()
-	e.Plugins().onBeforeServerStart()
+	e.watchConfig()
+	e.watchI18n()
+	e.setupTracing()
+	e.startup()
 
 	go e.startHTTPServer()
+	if e.redirectServer != nil {
+		go e.startRedirectServer()
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -120,6 +180,22 @@ func (e *Engine) Enforcer() *casbin.Enforcer {
 	return e.enforcer
 }
 
+// ReloadPolicy 从存储重新加载 Casbin 策略，并在启用策略监听器时通知其他实例同步
+func (e *Engine) ReloadPolicy() error {
+	if e.enforcer == nil {
+		return errAuthorizationUnavailable
+	}
+	if err := e.enforcer.LoadPolicy(); err != nil {
+		return fmt.Errorf("重新加载Casbin策略失败：%w", err)
+	}
+	if w := e.policyWatcher; w != nil {
+		if err := w.Update(); err != nil {
+			return fmt.Errorf("通知其他实例同步Casbin策略失败：%w", err)
+		}
+	}
+	return nil
+}
+
 // Logger 日志记录器
 func (e *Engine) Logger() *slog.Logger {
 	return e.logger
@@ -167,9 +243,51 @@ func (e *Engine) NewPoolWithFunc(fn func(any), size int) (*ants.PoolWithFunc, er
 	return newPoolWithFunc(fn, size, e.logger)
 }
 
+// startup 执行启动阶段（插件钩子、控制器挂载、HTTP 服务器初始化）
+// 任一阶段发生致命错误（panic）时，先回滚已初始化插件再继续向上抛出
+func (e *Engine) startup() {
+	defer func() {
+		if r := recover(); r != nil {
+			e.Plugins().Rollback()
+			panic(r)
+		}
+	}()
+
+	e.Plugins().onBeforeMount()
+	e.mountHealthEndpoints()
+	e.mountControllers(e.basePath)
+	e.mountStatic()
+	e.checkAuthorization()
+	e.checkConfig()
+	e.Plugins().onAfterMount()
+	e.initializeHTTPServer()
+	e.Plugins().onBeforeServerStart()
+}
+
+// checkAuthorization 已注册授权中间件但权限控制器未初始化时告警，相关请求将统一返回 500
+func (e *Engine) checkAuthorization() {
+	if e.enforcer == nil && e.authzRegistered.Load() && e.logger != nil {
+		e.logger.Warn("已注册授权中间件，但Casbin权限控制器未初始化，受保护接口将返回内部错误")
+	}
+}
+
+// checkConfig 路由挂载完成后校验配置，问题仅记录告警不阻止启动
+func (e *Engine) checkConfig() {
+	if err := e.ValidateConfig(); err != nil && e.logger != nil {
+		e.logger.Warn("配置校验发现问题", "error", err)
+	}
+}
+
 // startHTTPServer 启动 HTTP 服务器
 func (e *Engine) startHTTPServer() {
-	if err := e.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+	var err error
+	if e.httpServer.TLSConfig != nil {
+		err = e.httpServer.ListenAndServeTLS(e.config.GetString("server.tls.cert_file"), e.config.GetString("server.tls.key_file"))
+	} else {
+		err = e.httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		e.Plugins().Rollback()
 		panic(fmt.Errorf("致命错误服务器运行：%w", err))
 	}
 }
@@ -179,17 +297,29 @@ func (e *Engine) mountControllers(basePath string) {
 	e.logger.Info("开始注册控制器路由到分组", "basePath", basePath, "count", len(e.controllerRegistry))
 
 	handlers := make([]gin.HandlerFunc, 0)
+	// server.cors.enabled=false 时不注册内置跨域中间件，便于以 CORSMiddlewareWithValidator 等自定义策略替换
+	if !e.config.IsSet("server.cors.enabled") || e.config.GetBool("server.cors.enabled") {
+		handlers = append(handlers, corsMiddleware(e))
+	}
 	handlers = append(
 		handlers,
-		corsMiddleware(e.Config()),
 		requestIDMiddleware(),
+		LoggerContextMiddleware(e),
 		requestTimeMiddleware(),
+		responseEnvelopeMiddleware(e),
 		i18nMiddleware(e),
 		validationTranslatorMiddleware(e),
 		containerMiddleware(e),
 	)
 	handlers = append(handlers, e.middlewareManager.getGlobals()...)
 	handlers = append(handlers, errorHandlerMiddleware(e))
+	// 请求 URI 与请求体大小限制需位于错误处理中间件之内，超限错误才能被统一渲染
+	if limit := maxURILength(e.config); limit > 0 {
+		handlers = append(handlers, URILengthLimitMiddleware(limit))
+	}
+	if limit := e.config.GetInt64("server.max_body_bytes"); limit > 0 {
+		handlers = append(handlers, bodyLimitMiddleware(limit, e.config.GetInt64("server.max_stream_body_bytes")))
+	}
 	rg := e.router.Group(basePath, handlers...)
 
 	if e.config.GetBool("swagger.enabled") {
@@ -223,13 +353,78 @@ func (e *Engine) mountControllers(basePath string) {
 }
 
 // initializeHTTPServer 创建 HTTP 服务器实例
+// 启用 TLS 时使用 HTTPS（支持 HTTP/2），并按 server.tls.client_ca / client_auth 校验客户端证书（mTLS）；
+// 配置 server.tls.redirect_http_port 时另建明文监听，将请求 301 跳转到 HTTPS
+//
+// 超时配置（未配置或为 0 时使用默认值，负数表示不限制）：
+//   - server.read_header_timeout：读取请求头超时，默认 10s
+//   - server.read_timeout：读取整个请求（含请求体）超时，默认 60s；StreamBody 读取的流式上传不受此限制，
+//     其他方式读取的大文件上传需相应调大
+//   - server.write_timeout：从读完请求头到写完响应的超时，默认 60s，须大于 TimeoutMiddleware 的时长，
+//     否则超时响应尚未写出连接即被关闭
+//   - server.idle_timeout：keep-alive 连接空闲超时，默认 120s
 func (e *Engine) initializeHTTPServer() {
+	tlsConfig, err := newServerTLSConfig(e.config)
+	if err != nil {
+		panic(fmt.Errorf("致命错误初始化 TLS 配置：%w", err))
+	}
+	addr := e.config.GetString("server.address")
 	e.httpServer = &http.Server{
-		Addr:    e.config.GetString("server.address"),
-		Handler: e.router,
+		Addr:              addr,
+		Handler:           e.router,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: serverTimeout(e.config, "server.read_header_timeout", defaultReadHeaderTimeout),
+		ReadTimeout:       serverTimeout(e.config, "server.read_timeout", defaultReadTimeout),
+		WriteTimeout:      serverTimeout(e.config, "server.write_timeout", defaultWriteTimeout),
+		IdleTimeout:       serverTimeout(e.config, "server.idle_timeout", defaultIdleTimeout),
+	}
+	if port := e.config.GetInt("server.tls.redirect_http_port"); tlsConfig != nil && port > 0 {
+		e.redirectServer = &http.Server{
+			Addr:              ":" + strconv.Itoa(port),
+			Handler:           httpsRedirectHandler(addr),
+			ReadHeaderTimeout: e.httpServer.ReadHeaderTimeout,
+			IdleTimeout:       e.httpServer.IdleTimeout,
+		}
 	}
 }
 
+// serverTimeout 读取服务器超时配置：未配置或为 0 时返回默认值，负数返回 0（http.Server 视为不限制）
+func serverTimeout(cfg *viper.Viper, key string, def time.Duration) time.Duration {
+	d := cfg.GetDuration(key)
+	switch {
+	case d < 0:
+		return 0
+	case d == 0:
+		return def
+	default:
+		return d
+	}
+}
+
+// startRedirectServer 启动 HTTP 跳转 HTTPS 的明文监听
+func (e *Engine) startRedirectServer() {
+	if err := e.redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		e.Plugins().Rollback()
+		panic(fmt.Errorf("致命错误跳转服务器运行：%w", err))
+	}
+}
+
+// httpsRedirectHandler 将明文请求永久跳转到相同主机的 HTTPS 地址
+// HTTPS 端口取自 server.address，为 443 或未指定时跳转地址不带端口
+func httpsRedirectHandler(httpsAddr string) http.Handler {
+	_, httpsPort, _ := net.SplitHostPort(httpsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
 // shutdownCron 优雅停止 cron 任务
 func (e *Engine) shutdownCron() {
 	stopCtx := e.cron.Stop()
@@ -238,14 +433,14 @@ func (e *Engine) shutdownCron() {
 
 // shutdownHTTPServer 优雅关闭 HTTP 服务器
 func (e *Engine) shutdownHTTPServer() {
-	timeout := e.config.GetDuration("server.shutdown_timeout")
-	if timeout <= 0 {
-		timeout = defaultShutdownTimeout
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), e.shutdownTimeout())
 	defer cancel()
 
+	if e.redirectServer != nil {
+		if err := e.redirectServer.Shutdown(ctx); err != nil && e.logger != nil {
+			e.logger.Error("HTTP 跳转服务器关闭失败", "error", err)
+		}
+	}
 	if err := e.httpServer.Shutdown(ctx); err != nil {
 		if e.logger != nil {
 			e.logger.Error("HTTP 服务器关闭失败", "error", err)
@@ -269,6 +464,13 @@ func (e *Engine) closeEventBus() {
 	}
 }
 
+// closePolicyWatcher 停止 Casbin 策略监听器（需在关闭事件总线前执行）
+func (e *Engine) closePolicyWatcher() {
+	if e.policyWatcher != nil {
+		e.policyWatcher.Close()
+	}
+}
+
 // releasePool 释放协程池资源
 func (e *Engine) releasePool() {
 	if e.pool != nil {
@@ -280,6 +482,8 @@ func (e *Engine) shutdown() {
 	e.Plugins().onShutdown()
 	e.shutdownCron()
 	e.shutdownHTTPServer()
+	e.runShutdownHooks()
+	e.closePolicyWatcher()
 	e.closeEventBus()
 	e.releasePool()
 }
@@ -292,6 +496,8 @@ func (e *Engine) doPackage(injector ...do.Injector) {
 		do.Eager(e.EventBus()),
 		do.Eager(e.Pool()),
 		do.Eager(e.Enforcer()),
+		do.Eager[TxRunner](e),
+		do.Eager(e.Validator()),
 	)
 
 	if len(injector) > 0 {
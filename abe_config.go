@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -16,7 +17,7 @@ import (
 const (
 	envPrefix        = "ABE"    // 环境变量前缀
 	configName       = "config" // 配置文件名称（不含扩展名）
-	configType       = "yaml"   // 配置文件类型
+	configType       = "yaml"   // 默认配置文件类型
 	defaultConfigDir = "abe"    // 默认配置目录
 )
 
@@ -40,12 +41,22 @@ func newConfig() *viper.Viper {
 	// 创建 viper 实例
 	config := viper.New()
 
-	// 配置文件设置
-	config.SetConfigName(configName)
-	config.SetConfigType(configType)
-	// 添加配置文件搜索路径
-	for _, path := range getConfigPaths(configDir) {
-		config.AddConfigPath(path)
+	// 配置文件设置：优先使用探测到的配置文件，未找到时沿用默认搜索逻辑（报告未找到错误）
+	configPaths := getConfigPaths(configDir)
+	file, fileType, err := findConfigFile(configPaths, resolveConfigType(flags))
+	if err != nil {
+		panic(fmt.Errorf("致命错误选择配置文件：%w", err))
+	}
+	if file != "" {
+		config.SetConfigFile(file)
+		config.SetConfigType(fileType)
+	} else {
+		config.SetConfigName(configName)
+		config.SetConfigType(configType)
+		// 添加配置文件搜索路径
+		for _, path := range configPaths {
+			config.AddConfigPath(path)
+		}
 	}
 
 	// 读取配置文件
@@ -81,12 +92,62 @@ func getConfigPaths(configDir string) []string {
 	return paths
 }
 
+// configFileExts 配置文件扩展名探测顺序
+var configFileExts = []string{"yaml", "yml", "toml", "json"}
+
+// resolveConfigType 读取显式指定的配置文件类型：--config-type 优先，其次 ABE_CONFIG_TYPE 环境变量
+func resolveConfigType(flags *pflag.FlagSet) string {
+	t, _ := flags.GetString("config-type")
+	if t == "" {
+		t = os.Getenv(envPrefix + "_CONFIG_TYPE")
+	}
+	t = strings.ToLower(strings.TrimSpace(t))
+	if t == "yml" {
+		t = "yaml"
+	}
+	return t
+}
+
+// findConfigFile 按搜索路径顺序探测配置文件，返回首个命中的文件及其类型
+// - 指定 configType 时仅匹配该类型的文件（yaml 同时匹配 .yml）
+// - 未指定时按 yaml、yml、toml、json 顺序探测；同一目录存在多个配置文件时返回错误，避免静默选错
+// - 均未找到时返回空字符串
+func findConfigFile(paths []string, configType string) (string, string, error) {
+	if configType != "" && !slices.Contains(configFileExts, configType) {
+		return "", "", fmt.Errorf("不支持的配置文件类型 %q，可选值：yaml、toml、json", configType)
+	}
+	for _, dir := range paths {
+		var found []string
+		for _, ext := range configFileExts {
+			if configType != "" && ext != configType && !(configType == "yaml" && ext == "yml") {
+				continue
+			}
+			fp := filepath.Join(dir, configName+"."+ext)
+			if info, err := os.Stat(fp); err == nil && !info.IsDir() {
+				found = append(found, fp)
+			}
+		}
+		switch {
+		case len(found) == 1:
+			ext := strings.TrimPrefix(filepath.Ext(found[0]), ".")
+			if ext == "yml" {
+				ext = "yaml"
+			}
+			return found[0], ext, nil
+		case len(found) > 1:
+			return "", "", fmt.Errorf("目录 %s 中存在多个配置文件 %v，请删除多余文件或通过 --config-type 指定类型", dir, found)
+		}
+	}
+	return "", "", nil
+}
+
 // createFlags 创建并定义所有命令行 flags
 func createFlags() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("abe", pflag.ContinueOnError)
 
 	// 配置目录 flag
 	flags.String("config-dir", defaultConfigDir, "config directory")
+	flags.String("config-type", "", "config file type (yaml, toml, json); probed from the config directory when empty")
 
 	// 服务器配置 flags（使用嵌套键格式以匹配配置文件结构）
 	flags.String("server.address", "", "server listen address (e.g., :8080)")
@@ -0,0 +1,153 @@
+package abe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ValidateConfig 按已启用的功能校验配置完整性，一次性返回全部问题（errors.Join 聚合）
+// 建议在 NewEngine 之后、注册路由并调用 Run 之前执行，以便在启动前发现部署配置错误；
+// Run 在挂载路由后也会再执行一次，将问题记录为告警
+//
+// 校验项：
+//   - database.*：主机、端口、用户名、库名必填，类型仅支持 mysql
+//   - auth.jwt_secret：已注册认证中间件时必填
+//   - logger.level：取值合法
+//   - app.timezone / cron.timezone：可加载的时区名称
+//   - i18n.default_language 可解析，i18n.message_paths 中的目录存在
+//   - validator.locale / validator.locales：均为支持的验证器语言
+//   - auth.trusted_header.proxies：IP 或 CIDR 格式有效
+//   - server.cors.allow_origins：re: 前缀的正则可编译
+//   - server.tls.*：启用时证书齐全，客户端 CA、校验模式与跳转端口组合有效
+//   - event.driver=kafka 时 event.kafka.brokers 必填，redis 时 event.redis.addr 必填，gochannel 时 event.buffer_size 不能为负数
+//   - casbin.model_path 文件存在，cron.lock.store / event.dead_letter.store / async_job.store / casbin.watcher 取值合法
+//
+// 使用示例:
+//
+//	engine := abe.NewEngine()
+//	if err := engine.ValidateConfig(); err != nil {
+//	    log.Fatalf("配置校验失败：\n%v", err)
+//	}
+func (e *Engine) ValidateConfig() error {
+	cfg := e.config
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	var db DbConfig
+	if err := cfg.UnmarshalKey("database", &db); err != nil {
+		add("database 配置解析失败：%w", err)
+	} else {
+		if db.Type != "" && !strings.EqualFold(db.Type, "mysql") {
+			add("database.type 仅支持 mysql，当前为 %q", db.Type)
+		}
+		if db.Host == "" {
+			add("database.host 未配置")
+		}
+		if db.Port <= 0 || db.Port > 65535 {
+			add("database.port 无效：%d", db.Port)
+		}
+		if db.User == "" {
+			add("database.user 未配置")
+		}
+		if db.DBName == "" {
+			add("database.dbname 未配置")
+		}
+	}
+
+	if e.authnRegistered.Load() && cfg.GetString("auth.jwt_secret") == "" {
+		add("已注册认证中间件，但 auth.jwt_secret 未配置")
+	}
+
+	if _, err := resolveLogLevel(cfg); err != nil {
+		add("logger.level 无效：%w", err)
+	}
+
+	if lang := cfg.GetString("i18n.default_language"); lang != "" {
+		if _, err := language.Parse(lang); err != nil {
+			add("i18n.default_language 无效：%q", lang)
+		}
+	}
+	for _, dir := range cfg.GetStringSlice("i18n.message_paths") {
+		if dir == "" {
+			continue
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			add("i18n.message_paths 中的目录不存在：%s", dir)
+		}
+	}
+
+	for _, key := range []string{"app.timezone", "cron.timezone"} {
+		if _, err := loadLocation(cfg, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if locale := cfg.GetString("validator.locale"); locale != "" {
+		if _, ok := validatorLocales[locale]; !ok {
+			add("validator.locale 不支持：%q", locale)
+		}
+	}
+	for _, locale := range getStringSlice(cfg, "validator.locales", nil) {
+		if _, ok := validatorLocales[locale]; !ok {
+			add("validator.locales 包含不支持的语言：%q", locale)
+		}
+	}
+
+	if _, err := newServerTLSConfig(cfg); err != nil {
+		add("server.tls 配置无效：%w", err)
+	}
+
+	for _, origin := range getStringSlice(cfg, "server.cors.allow_origins", nil) {
+		if expr, ok := strings.CutPrefix(strings.TrimSpace(origin), "re:"); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				add("server.cors.allow_origins 中的正则 %q 无效：%w", expr, err)
+			}
+		}
+	}
+
+	if _, err := loadTrustedHeaderConfig(cfg); err != nil {
+		add("auth.trusted_header 配置无效：%w", err)
+	}
+
+	switch driver := strings.ToLower(cfg.GetString("event.driver")); driver {
+	case "", eventDriverGoChannel:
+		if cfg.GetInt64("event.buffer_size") < 0 {
+			add("event.buffer_size 不能为负数：%d", cfg.GetInt64("event.buffer_size"))
+		}
+	case eventDriverKafka:
+		if len(getStringSlice(cfg, "event.kafka.brokers", nil)) == 0 {
+			add("event.driver=kafka 时 event.kafka.brokers 必填")
+		}
+	case eventDriverRedis:
+		if cfg.GetString("event.redis.addr") == "" {
+			add("event.driver=redis 时 event.redis.addr 必填")
+		}
+	default:
+		add("event.driver 不支持：%q（可选 gochannel、kafka、redis）", driver)
+	}
+
+	if path := strings.TrimSpace(cfg.GetString("casbin.model_path")); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			add("casbin.model_path 文件不存在：%s", path)
+		}
+	}
+	checkOneOf := func(key string, allowed ...string) {
+		if v := strings.ToLower(strings.TrimSpace(cfg.GetString(key))); v != "" && !slices.Contains(allowed, v) {
+			add("%s 不支持：%q（可选 %s）", key, v, strings.Join(allowed, "、"))
+		}
+	}
+	checkOneOf("casbin.watcher", "none", "eventbus")
+	checkOneOf("cron.lock.store", "database", "memory")
+	checkOneOf("event.dead_letter.store", "memory", "database")
+	checkOneOf("async_job.store", "memory", "database")
+
+	return errors.Join(errs...)
+}
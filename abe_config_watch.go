@@ -0,0 +1,158 @@
+package abe
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// defaultConfigWatchDebounce 配置文件变更事件的默认合并窗口
+// 编辑器保存文件时通常会连续触发多次写事件，窗口内的事件只触发一次重新加载
+const defaultConfigWatchDebounce = 500 * time.Millisecond
+
+// OnConfigChange 注册配置变更监听器
+// 配置文件变更并重新加载后，对每个取值发生变化（含新增、删除）的配置键调用一次 fn，键名为点号分隔的小写形式（如 "server.cors.allow_origins"）
+//
+// 注意：
+//   - 仅在启用配置热更新（config.watch，默认开启）且使用了配置文件时生效
+//   - 监听器在独立协程中依次调用，应避免长时间阻塞
+//
+// 使用示例:
+//
+//	e.OnConfigChange(func(key string) {
+//	    if strings.HasPrefix(key, "payment.") {
+//	        reloadPaymentClient(e.Config())
+//	    }
+//	})
+func (e *Engine) OnConfigChange(fn func(key string)) {
+	e.configMu.Lock()
+	defer e.configMu.Unlock()
+	e.configListeners = append(e.configListeners, fn)
+}
+
+// watchConfig 监听配置文件变更（config.watch=false 时关闭）
+// 变更事件按 config.watch_debounce 合并后重新计算变更键并通知监听器；日志级别随 logger.level / app.debug 实时调整
+func (e *Engine) watchConfig() {
+	if e.config.IsSet("config.watch") && !e.config.GetBool("config.watch") {
+		return
+	}
+	file := e.config.ConfigFileUsed()
+	if file == "" {
+		return
+	}
+	debounce := e.config.GetDuration("config.watch_debounce")
+	if debounce <= 0 {
+		debounce = defaultConfigWatchDebounce
+	}
+
+	e.configMu.Lock()
+	e.configSnapshot = flattenConfig(e.config)
+	e.configMu.Unlock()
+
+	e.OnConfigChange(func(key string) {
+		if strings.HasPrefix(key, "logger.") || key == "app.debug" {
+			e.reloadLogLevel()
+		}
+	})
+
+	var mu sync.Mutex
+	var timer *time.Timer
+	e.config.OnConfigChange(func(fsnotify.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(debounce, e.reloadConfig)
+	})
+	e.config.WatchConfig()
+
+	if e.logger != nil {
+		e.logger.Info("已启用配置文件热更新", "file", file, "debounce", debounce)
+	}
+}
+
+// reloadConfig 计算本次变更的配置键并依次通知监听器
+func (e *Engine) reloadConfig() {
+	e.configMu.Lock()
+	current := flattenConfig(e.config)
+	changed := changedConfigKeys(e.configSnapshot, current)
+	e.configSnapshot = current
+	listeners := slices.Clone(e.configListeners)
+	e.configMu.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+	if e.logger != nil {
+		e.logger.Info("配置文件已变更", "keys", changed)
+	}
+	for _, key := range changed {
+		for _, fn := range listeners {
+			e.notifyConfigListener(fn, key)
+		}
+	}
+}
+
+// notifyConfigListener 调用单个监听器，监听器 panic 时记录日志，不影响其余监听器
+func (e *Engine) notifyConfigListener(fn func(key string), key string) {
+	defer func() {
+		if r := recover(); r != nil && e.logger != nil {
+			e.logger.Error("配置变更监听器发生异常", "key", key, "panic", r)
+		}
+	}()
+	fn(key)
+}
+
+// reloadLogLevel 按当前配置重新计算日志级别，配置无效时保留原级别
+func (e *Engine) reloadLogLevel() {
+	if e.logLevel == nil {
+		return
+	}
+	level, err := resolveLogLevel(e.config)
+	if err != nil {
+		if e.logger != nil {
+			e.logger.Warn("日志级别配置无效，保持当前级别", "level", e.logLevel.Level(), "error", err)
+		}
+		return
+	}
+	if level == e.logLevel.Level() {
+		return
+	}
+	e.logLevel.Set(level)
+	if e.logger != nil {
+		e.logger.Info("日志级别已更新", "level", level)
+	}
+}
+
+// flattenConfig 将配置展开为 键 -> 值 的扁平映射
+func flattenConfig(cfg *viper.Viper) map[string]any {
+	keys := cfg.AllKeys()
+	m := make(map[string]any, len(keys))
+	for _, k := range keys {
+		m[k] = cfg.Get(k)
+	}
+	return m
+}
+
+// changedConfigKeys 返回两次快照间取值不同的配置键（按字典序）
+func changedConfigKeys(prev, current map[string]any) []string {
+	var changed []string
+	for k, v := range current {
+		if old, ok := prev[k]; !ok || !reflect.DeepEqual(old, v) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range prev {
+		if _, ok := current[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
@@ -1,19 +1,27 @@
 package abe
 
 import (
+	"fmt"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
 )
 
 // newCron 创建并返回一个新的定时任务管理器实例
 // 每次调用都会返回一个新的实例
-func newCron(logger *slog.Logger) *cron.Cron {
+// 调度时区取 cron.timezone，未配置时取 app.timezone，均未配置时使用本地时区
+func newCron(logger *slog.Logger, config *viper.Viper) *cron.Cron {
+	loc, err := loadLocation(config, "cron.timezone", "app.timezone")
+	if err != nil {
+		panic(fmt.Errorf("致命错误加载定时任务时区：%w", err))
+	}
 	slogLogger := slogCronLogger{logger: logger}
 	corn := cron.New(
 		cron.WithSeconds(),
-		cron.WithLocation(time.Local),
+		cron.WithLocation(loc),
 		cron.WithLogger(slogLogger),
 		cron.WithChain(
 			cron.Recover(slogLogger),
@@ -37,3 +45,99 @@ func (l slogCronLogger) Error(err error, msg string, keysAndValues ...interface{
 	kv := append([]interface{}{"error", err}, keysAndValues...)
 	l.logger.Error(msg, kv...)
 }
+
+// CronJobInfo 具名定时任务信息
+type CronJobInfo struct {
+	Name string       `json:"name"`          // 任务名
+	Spec string       `json:"spec"`          // 调度表达式（含秒字段）
+	ID   cron.EntryID `json:"id"`            // 调度器中的条目 ID
+	Next time.Time    `json:"next"`          // 下次执行时间
+	Prev time.Time    `json:"prev,omitzero"` // 上次执行时间，未执行过为零值
+}
+
+// cronJob 具名定时任务的登记信息
+type cronJob struct {
+	id   cron.EntryID
+	spec string
+}
+
+// AddCronJob 添加具名定时任务
+//
+// 参数:
+//   - name: 任务名，全局唯一
+//   - spec: 调度表达式，支持秒字段（如 "0 */5 * * * *"）及 "@every 1m" 等描述符
+//   - fn: 任务函数
+//   - opts: 任务选项，如 WithCronLock
+//
+// 返回:
+//   - cron.EntryID: 调度器中的条目 ID
+//   - error: 任务名为空、重名或调度表达式无效时返回错误
+func (e *Engine) AddCronJob(name, spec string, fn func(), opts ...CronJobOption) (cron.EntryID, error) {
+	if name == "" {
+		return 0, fmt.Errorf("定时任务名不能为空")
+	}
+
+	var o cronJobOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.lockName != "" {
+		if o.lockTTL <= 0 {
+			return 0, fmt.Errorf("定时任务 %s 的锁有效期必须大于 0", name)
+		}
+		fn = e.lockedCronJob(name, o.lockName, o.lockTTL, fn)
+	}
+
+	e.cronJobsMu.Lock()
+	defer e.cronJobsMu.Unlock()
+
+	if _, exists := e.cronJobs[name]; exists {
+		return 0, fmt.Errorf("定时任务 %s 已存在", name)
+	}
+	id, err := e.cron.AddFunc(spec, fn)
+	if err != nil {
+		return 0, fmt.Errorf("添加定时任务 %s 失败：%w", name, err)
+	}
+	if e.cronJobs == nil {
+		e.cronJobs = make(map[string]cronJob)
+	}
+	e.cronJobs[name] = cronJob{id: id, spec: spec}
+	e.logger.Info("定时任务已添加", "name", name, "spec", spec, "entry_id", id)
+	return id, nil
+}
+
+// RemoveCronJob 移除具名定时任务；任务不存在时返回 false
+// 正在执行中的任务不会被中断，仅停止后续调度
+func (e *Engine) RemoveCronJob(name string) bool {
+	e.cronJobsMu.Lock()
+	defer e.cronJobsMu.Unlock()
+
+	job, exists := e.cronJobs[name]
+	if !exists {
+		return false
+	}
+	e.cron.Remove(job.id)
+	delete(e.cronJobs, name)
+	e.logger.Info("定时任务已移除", "name", name, "entry_id", job.id)
+	return true
+}
+
+// ListCronJobs 返回所有具名定时任务信息，按任务名排序
+func (e *Engine) ListCronJobs() []CronJobInfo {
+	e.cronJobsMu.Lock()
+	defer e.cronJobsMu.Unlock()
+
+	list := make([]CronJobInfo, 0, len(e.cronJobs))
+	for name, job := range e.cronJobs {
+		entry := e.cron.Entry(job.id)
+		list = append(list, CronJobInfo{
+			Name: name,
+			Spec: job.spec,
+			ID:   job.id,
+			Next: entry.Next,
+			Prev: entry.Prev,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -1,23 +1,34 @@
 package abe
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
 
 	"github.com/casbin/casbin/v3"
 	"github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/persist"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 )
 
+var _ persist.Watcher = (*eventBusWatcher)(nil)
+
+// Casbin 内置模型名称（casbin.model）
+const (
+	casbinModelRBAC       = "rbac"        // 默认：sub, obj, act
+	casbinModelRBACDomain = "rbac_domain" // 多租户：sub, dom, obj, act
+)
+
 // newEnforcer 使用 GORM 适配器初始化 Casbin 权限控制器
 // 失败时直接 panic，与 newDB 等初始化风格保持一致
 func newEnforcer(db *gorm.DB, logger *slog.Logger, cfg *viper.Viper) *casbin.Enforcer {
-	m, err := model.NewModelFromString(rbacModel)
-	if err != nil {
-		panic(fmt.Errorf("加载Casbin模型失败: %w", err))
-	}
+	m, modelName := loadCasbinModel(cfg)
 
 	table := cfg.GetString("casbin.policy_table")
 	if table == "" {
@@ -38,7 +49,7 @@ func newEnforcer(db *gorm.DB, logger *slog.Logger, cfg *viper.Viper) *casbin.Enf
 		panic(fmt.Errorf("加载Casbin策略失败: %w", err))
 	}
 	if logger != nil {
-		logger.Info("Casbin权限控制器已初始化")
+		logger.Info("Casbin权限控制器已初始化", "model", modelName)
 	}
 	return enf
 }
@@ -59,3 +70,165 @@ e = some(where (p.eft == allow))
 [matchers]
 m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && (r.act == p.act || p.act == "*")
 `
+
+// loadCasbinModel 加载 Casbin 模型
+// - 配置 casbin.model_path 时从文件加载（适用于 ABAC 或自定义匹配器）
+// - 否则按 casbin.model 选择内置模型（默认 rbac）
+// 返回模型及其描述（文件路径或内置模型名），解析失败时 panic
+func loadCasbinModel(cfg *viper.Viper) (model.Model, string) {
+	if path := strings.TrimSpace(cfg.GetString("casbin.model_path")); path != "" {
+		m, err := model.NewModelFromFile(path)
+		if err != nil {
+			panic(fmt.Errorf("加载Casbin模型文件失败（casbin.model_path=%s）: %w", path, err))
+		}
+		return m, path
+	}
+
+	modelName := strings.ToLower(strings.TrimSpace(cfg.GetString("casbin.model")))
+	if modelName == "" {
+		modelName = casbinModelRBAC
+	}
+	var text string
+	switch modelName {
+	case casbinModelRBAC:
+		text = rbacModel
+	case casbinModelRBACDomain:
+		text = rbacDomainModel
+	default:
+		panic(fmt.Errorf("不支持的Casbin模型: %q（可选 %s、%s）", modelName, casbinModelRBAC, casbinModelRBACDomain))
+	}
+	m, err := model.NewModelFromString(text)
+	if err != nil {
+		panic(fmt.Errorf("加载Casbin模型失败: %w", err))
+	}
+	return m, modelName
+}
+
+// casbinPolicyReloadTopic 策略变更通知主题
+const casbinPolicyReloadTopic = "casbin.policy.reload"
+
+// newPolicyWatcher 按 casbin.watcher 配置创建策略监听器
+// - 未配置或为 none：返回 nil，不启用多实例同步
+// - eventbus：基于事件总线在多实例间同步策略变更（收到通知后重新 LoadPolicy）
+func newPolicyWatcher(cfg *viper.Viper, bus EventBus, logger *slog.Logger) persist.Watcher {
+	kind := strings.ToLower(strings.TrimSpace(cfg.GetString("casbin.watcher")))
+	switch kind {
+	case "", "none":
+		return nil
+	case "eventbus":
+		w, err := newEventBusWatcher(bus, logger)
+		if err != nil {
+			panic(fmt.Errorf("创建Casbin策略监听器失败: %w", err))
+		}
+		if logger != nil {
+			logger.Info("Casbin策略监听器已启用", "watcher", kind, "topic", casbinPolicyReloadTopic)
+		}
+		return w
+	default:
+		panic(fmt.Errorf("不支持的Casbin策略监听器: %q（可选 none、eventbus）", kind))
+	}
+}
+
+// newEnforcerWithWatcher 初始化 Casbin 权限控制器并挂载策略监听器（watcher 为 nil 时不挂载）
+// 挂载后，通过 Enforcer 修改策略（AddPolicy、RemovePolicy 等）会自动通知其他实例
+func newEnforcerWithWatcher(db *gorm.DB, logger *slog.Logger, cfg *viper.Viper, watcher persist.Watcher) *casbin.Enforcer {
+	enf := newEnforcer(db, logger, cfg)
+	if watcher == nil {
+		return enf
+	}
+	if err := enf.SetWatcher(watcher); err != nil {
+		panic(fmt.Errorf("设置Casbin策略监听器失败: %w", err))
+	}
+	return enf
+}
+
+// eventBusWatcher 基于 EventBus 的 Casbin 策略监听器（实现 persist.Watcher）
+// 本实例发布的通知会被自身忽略，避免重复加载
+type eventBusWatcher struct {
+	bus        EventBus
+	logger     *slog.Logger
+	instanceID string
+	cancel     context.CancelFunc
+
+	mu       sync.RWMutex
+	callback func(string)
+}
+
+// watcherInstanceMetadataKey 消息元数据中标识发布实例的键
+const watcherInstanceMetadataKey = "casbin_watcher_instance"
+
+func newEventBusWatcher(bus EventBus, logger *slog.Logger) (*eventBusWatcher, error) {
+	if bus == nil {
+		return nil, errors.New("事件总线未初始化")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := bus.Subscribe(ctx, casbinPolicyReloadTopic)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	w := &eventBusWatcher{
+		bus:        bus,
+		logger:     logger,
+		instanceID: uuid.New().String(),
+		cancel:     cancel,
+	}
+	go w.listen(ch)
+	return w, nil
+}
+
+// listen 接收其他实例的策略变更通知并触发回调
+func (w *eventBusWatcher) listen(ch <-chan *EventMessage) {
+	for msg := range ch {
+		if msg.Metadata(watcherInstanceMetadataKey) != w.instanceID {
+			w.mu.RLock()
+			cb := w.callback
+			w.mu.RUnlock()
+			if cb != nil {
+				if w.logger != nil {
+					w.logger.Info("收到Casbin策略变更通知，重新加载策略", "from", msg.Metadata(watcherInstanceMetadataKey))
+				}
+				cb(string(msg.Payload()))
+			}
+		}
+		msg.Ack()
+	}
+}
+
+// SetUpdateCallback 设置收到变更通知时的回调（通常为 Enforcer.LoadPolicy）
+func (w *eventBusWatcher) SetUpdateCallback(fn func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = fn
+	return nil
+}
+
+// Update 向其他实例广播策略变更通知
+func (w *eventBusWatcher) Update() error {
+	msg := NewMessage([]byte(w.instanceID))
+	msg.SetMetadata(watcherInstanceMetadataKey, w.instanceID)
+	return w.bus.Publish(casbinPolicyReloadTopic, msg)
+}
+
+// Close 停止监听
+func (w *eventBusWatcher) Close() {
+	w.cancel()
+}
+
+// rbacDomainModel 多租户 RBAC 模型：角色继承与策略均按域（租户）隔离
+const rbacDomainModel = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && keyMatch2(r.obj, p.obj) && (r.act == p.act || p.act == "*")
+`
@@ -3,16 +3,20 @@ package abe
 import (
 	"context"
 	"log/slog"
+	"sort"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 )
 
 // EventMessage 消息结构体
 type EventMessage struct {
 	msg *message.Message
+	err error // 处理错误，确认前记录，供同步发布回执使用
 }
 
 // NewMessage 创建一个新的 EventMessage 实例。
@@ -35,6 +39,11 @@ func (m *EventMessage) Acked() <-chan struct{} {
 	return m.msg.Acked()
 }
 
+// fail 记录处理错误，须在 Ack/Nack 之前调用
+func (m *EventMessage) fail(err error) {
+	m.err = err
+}
+
 // Nack 负确认消息，用于拒绝处理消息。
 func (m *EventMessage) Nack() bool {
 	return m.msg.Nack()
@@ -49,6 +58,45 @@ func (m *EventMessage) UUID() string {
 	return m.msg.UUID
 }
 
+// Metadata 返回指定键的元数据值，不存在时返回空字符串。
+func (m *EventMessage) Metadata(key string) string {
+	return m.msg.Metadata.Get(key)
+}
+
+// SetMetadata 设置元数据键值。
+func (m *EventMessage) SetMetadata(key, value string) {
+	m.msg.Metadata.Set(key, value)
+}
+
+// CorrelationID 返回消息的关联 ID（通常为发起请求的请求 ID）。
+func (m *EventMessage) CorrelationID() string {
+	return middleware.MessageCorrelationID(m.msg)
+}
+
+// WithCorrelationID 设置消息的关联 ID 并返回自身，便于链式调用。
+// 与 Watermill 的 correlation_id 元数据约定保持一致。
+func (m *EventMessage) WithCorrelationID(id string) *EventMessage {
+	if id != "" {
+		m.msg.Metadata.Set(middleware.CorrelationIDMetadataKey, id)
+	}
+	return m
+}
+
+// NewRequestMessage 在请求上下文中创建消息，并以当前请求 ID 作为关联 ID，
+// 使事件总线日志可与 HTTP 访问日志关联；请求携带链路上下文时一并注入（见 TracingMiddleware）。
+func NewRequestMessage(ctx *gin.Context, payload []byte) *EventMessage {
+	return NewMessage(payload).WithCorrelationID(GetRequestID(ctx)).WithTraceContext(ctx.Request.Context())
+}
+
+// messageLogFields 返回消息用于日志的关联字段
+func messageLogFields(topic string, m *message.Message) watermill.LogFields {
+	fields := watermill.LogFields{"topic": topic, "message_uuid": m.UUID}
+	if cid := middleware.MessageCorrelationID(m); cid != "" {
+		fields[middleware.CorrelationIDMetadataKey] = cid
+	}
+	return fields
+}
+
 // EventBus 为事件总线的抽象接口，按消息层面暴露能力。
 // 通过泛型辅助函数提供类型安全的 publish/subscribe。
 type EventBus interface {
@@ -59,8 +107,9 @@ type EventBus interface {
 
 // goChannelBus 基于 Watermill GoChannel 的进程内事件总线实现。
 type goChannelBus struct {
-	ps     *gochannel.GoChannel
-	logger watermill.LoggerAdapter
+	ps      *gochannel.GoChannel
+	logger  watermill.LoggerAdapter
+	replies syncReplies
 }
 
 // newGoChannelBus 创建一个基于 GoChannel 的事件总线。
@@ -77,18 +126,33 @@ func newGoChannelBus(cfg *gochannel.Config, logger watermill.LoggerAdapter) *goC
 	return &goChannelBus{ps: ps, logger: logger}
 }
 
+// defaultEventBufferSize 进程内总线每个订阅者的默认输出缓冲大小
+const defaultEventBufferSize = 256
+
+// newGoChannelConfig 从配置构建 GoChannel 参数，优先级：环境变量/CLI Flag/配置文件 > 默认值
+//
+// 配置项:
+//   - event.buffer_size：每个订阅者的输出通道缓冲（兼容旧键 event.output_buffer），默认 256。
+//     缓冲越大越能吸收突发流量、减少发布方阻塞，但每个订阅者最多常驻等量消息，占用内存随之增加，
+//     且消费变慢时积压不会及时反馈给发布方；缓冲越小则越早产生背压，发布延迟随之上升
+//   - event.block_publish_until_ack：发布时等待订阅者确认，保证同一发布方的消息按发布顺序到达
+//     订阅者（按键顺序消费依赖此项），代价是发布延迟取决于最慢的订阅者
+//   - event.persistent：在内存中保留已发布消息并重放给后续订阅者，消息不会释放，仅适用于测试或小规模场景
 func newGoChannelConfig(config *viper.Viper) *gochannel.Config {
-	// 从配置读取缓冲大小，优先级：环境变量/CLI Flag/配置文件 > 默认值
-	var buf int64 = 0
-	if config != nil {
-		buf = int64(config.GetInt("event.output_buffer"))
+	cfg := &gochannel.Config{OutputChannelBuffer: defaultEventBufferSize}
+	if config == nil {
+		return cfg
 	}
+	buf := config.GetInt64("event.buffer_size")
 	if buf <= 0 {
-		buf = 64
+		buf = config.GetInt64("event.output_buffer")
 	}
-	return &gochannel.Config{
-		OutputChannelBuffer: buf,
+	if buf > 0 {
+		cfg.OutputChannelBuffer = buf
 	}
+	cfg.BlockPublishUntilSubscriberAck = config.GetBool("event.block_publish_until_ack")
+	cfg.Persistent = config.GetBool("event.persistent")
+	return cfg
 }
 
 func newGoChannelLogger(logger *slog.Logger) watermill.LoggerAdapter {
@@ -102,6 +166,7 @@ func (b *goChannelBus) Publish(topic string, msg ...*EventMessage) error {
 	msgWatermill := make([]*message.Message, len(msg))
 	for i, m := range msg {
 		msgWatermill[i] = m.msg
+		b.logger.Trace("发布事件消息", messageLogFields(topic, m.msg))
 	}
 	return b.ps.Publish(topic, msgWatermill...)
 }
@@ -117,12 +182,34 @@ func (b *goChannelBus) Subscribe(ctx context.Context, topic string) (<-chan *Eve
 	go func() {
 		defer close(msgCh)
 		for msg := range ch {
-			msgCh <- &EventMessage{msg: msg}
+			b.logger.Trace("接收事件消息", messageLogFields(topic, msg))
+			m := &EventMessage{msg: msg}
+			b.replies.watch(m)
+			msgCh <- m
 		}
 	}()
 	return msgCh, nil
 }
 
+// publishSync 发布消息并等待首个订阅者的确认回执。
+// GoChannel 会为每个订阅者复制消息，回执通过元数据中的原消息 UUID 关联。
+func (b *goChannelBus) publishSync(ctx context.Context, topic string, msg *EventMessage) error {
+	id := msg.UUID()
+	msg.SetMetadata(eventSyncMetadataKey, id)
+	reply := b.replies.register(id)
+	defer b.replies.remove(id)
+
+	if err := b.Publish(topic, msg); err != nil {
+		return err
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // close 关闭底层 Pub/Sub。
 func (b *goChannelBus) close() error {
 	return b.ps.Close()
@@ -170,13 +257,16 @@ func (l *slogAdapter) Error(msg string, err error, fields watermill.LogFields) {
 }
 
 func (l *slogAdapter) log(level slog.Level, msg string, err error, fields watermill.LogFields) {
-	// 合并字段
-	attrs := make([]slog.Attr, 0, len(l.fields)+len(fields)+1)
-	for k, v := range l.fields {
-		attrs = append(attrs, slog.Any(k, v))
+	// 合并字段：调用时传入的字段覆盖 With() 链上的同名字段（如 correlation_id），避免重复输出
+	merged := l.fields.Add(fields)
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
 	}
-	for k, v := range fields {
-		attrs = append(attrs, slog.Any(k, v))
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(merged)+1)
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, merged[k]))
 	}
 	if err != nil {
 		attrs = append(attrs, slog.String("error", err.Error()))
@@ -0,0 +1,145 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/spf13/viper"
+)
+
+// 事件总线驱动
+const (
+	eventDriverGoChannel = "gochannel" // 进程内（默认）
+	eventDriverKafka     = "kafka"     // Kafka
+	eventDriverRedis     = "redis"     // Redis Stream
+)
+
+// newEventBus 按 event.driver 配置创建事件总线
+// - gochannel（默认）：进程内总线，消息不持久化、不跨进程
+// - kafka：基于 Kafka 的总线，配置见 newKafkaBus
+// - redis：基于 Redis Stream 的总线，配置见 newRedisBus
+func newEventBus(config *viper.Viper, cfg *gochannel.Config, logger watermill.LoggerAdapter) EventBus {
+	driver := strings.ToLower(config.GetString("event.driver"))
+	switch driver {
+	case "", eventDriverGoChannel:
+		return newGoChannelBus(cfg, logger)
+	case eventDriverKafka:
+		bus, err := newKafkaBus(config, logger)
+		if err != nil {
+			panic(fmt.Errorf("初始化 Kafka 事件总线失败：%w", err))
+		}
+		return bus
+	case eventDriverRedis:
+		bus, err := newRedisBus(config, logger)
+		if err != nil {
+			panic(fmt.Errorf("初始化 Redis 事件总线失败：%w", err))
+		}
+		return bus
+	default:
+		panic(fmt.Errorf("不支持的事件总线驱动：%s（可选 gochannel、kafka、redis）", driver))
+	}
+}
+
+// kafkaBus 基于 Watermill Kafka 的事件总线实现
+// 消息的聚合键（见 EventMessage.WithKey）用作 Kafka 分区键，保证同一聚合的消息落在同一分区、按序消费
+type kafkaBus struct {
+	publisher  *kafka.Publisher
+	subscriber *kafka.Subscriber
+	logger     watermill.LoggerAdapter
+}
+
+// newKafkaBus 创建 Kafka 事件总线
+//
+// 配置:
+//   - event.kafka.brokers: Broker 地址列表（必填），支持数组或逗号分隔字符串
+//   - event.kafka.consumer_group: 消费者组，多实例共享同一组时每条消息仅被组内一个实例消费；
+//     为空时每个实例独立消费全部分区
+//   - event.kafka.initial_offset: 无已提交位点时的起始位置，newest（默认）或 oldest
+func newKafkaBus(config *viper.Viper, logger watermill.LoggerAdapter) (*kafkaBus, error) {
+	brokers := getStringSlice(config, "event.kafka.brokers", nil)
+	if len(brokers) == 0 {
+		return nil, errors.New("未配置 event.kafka.brokers")
+	}
+	marshaler := kafkaKeyedMarshaler{}
+
+	publisher, err := kafka.NewPublisher(kafka.PublisherConfig{
+		Brokers:   brokers,
+		Marshaler: marshaler,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("创建 Kafka 发布者失败：%w", err)
+	}
+
+	saramaCfg := kafka.DefaultSaramaSubscriberConfig()
+	if strings.EqualFold(config.GetString("event.kafka.initial_offset"), "oldest") {
+		saramaCfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	}
+	subscriber, err := kafka.NewSubscriber(kafka.SubscriberConfig{
+		Brokers:               brokers,
+		Unmarshaler:           marshaler,
+		ConsumerGroup:         config.GetString("event.kafka.consumer_group"),
+		OverwriteSaramaConfig: saramaCfg,
+	}, logger)
+	if err != nil {
+		_ = publisher.Close()
+		return nil, fmt.Errorf("创建 Kafka 订阅者失败：%w", err)
+	}
+
+	return &kafkaBus{publisher: publisher, subscriber: subscriber, logger: logger}, nil
+}
+
+// Publish 同步发布消息，返回时消息已被 Broker 确认。
+func (b *kafkaBus) Publish(topic string, msg ...*EventMessage) error {
+	msgWatermill := make([]*message.Message, len(msg))
+	for i, m := range msg {
+		msgWatermill[i] = m.msg
+		b.logger.Trace("发布事件消息", messageLogFields(topic, m.msg))
+	}
+	return b.publisher.Publish(topic, msgWatermill...)
+}
+
+// Subscribe 订阅主题；消息需 Ack 后才会提交位点，Nack 的消息将被重新投递。
+func (b *kafkaBus) Subscribe(ctx context.Context, topic string) (<-chan *EventMessage, error) {
+	ch, err := b.subscriber.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	msgCh := make(chan *EventMessage)
+	go func() {
+		defer close(msgCh)
+		for msg := range ch {
+			b.logger.Trace("接收事件消息", messageLogFields(topic, msg))
+			msgCh <- &EventMessage{msg: msg}
+		}
+	}()
+	return msgCh, nil
+}
+
+// close 先关闭发布者（等待在途消息发送完成），再关闭订阅者。
+func (b *kafkaBus) close() error {
+	return errors.Join(b.publisher.Close(), b.subscriber.Close())
+}
+
+// kafkaKeyedMarshaler 在默认编解码的基础上，将消息聚合键作为 Kafka 分区键
+// 未设置聚合键的消息不设置分区键，由分区器随机分配
+type kafkaKeyedMarshaler struct {
+	kafka.DefaultMarshaler
+}
+
+func (m kafkaKeyedMarshaler) Marshal(topic string, msg *message.Message) (*sarama.ProducerMessage, error) {
+	kafkaMsg, err := m.DefaultMarshaler.Marshal(topic, msg)
+	if err != nil {
+		return nil, err
+	}
+	if key := msg.Metadata.Get(EventKeyMetadataKey); key != "" {
+		kafkaMsg.Key = sarama.StringEncoder(key)
+	}
+	return kafkaMsg, nil
+}
@@ -0,0 +1,93 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill-redisstream/pkg/redisstream"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// redisBus 基于 Watermill Redis Stream 的事件总线实现
+// 每个主题对应一个 Redis Stream；适合不想引入 Kafka、又需要跨进程投递的场景
+type redisBus struct {
+	publisher  *redisstream.Publisher
+	subscriber *redisstream.Subscriber
+	logger     watermill.LoggerAdapter
+}
+
+// newRedisBus 创建 Redis Stream 事件总线
+//
+// 配置:
+//   - event.redis.addr: Redis 地址（必填），如 127.0.0.1:6379
+//   - event.redis.password: 密码，可选
+//   - event.redis.db: 数据库编号，默认 0
+//   - event.redis.consumer_group: 消费者组，多实例共享同一组时每条消息仅被组内一个实例消费；
+//     为空时每个实例独立接收订阅之后发布的全部消息
+//   - event.redis.max_len: 每个 Stream 保留的近似最大消息数，默认 0 不限制
+func newRedisBus(config *viper.Viper, logger watermill.LoggerAdapter) (*redisBus, error) {
+	addr := config.GetString("event.redis.addr")
+	if addr == "" {
+		return nil, errors.New("未配置 event.redis.addr")
+	}
+	opts := &redis.Options{
+		Addr:     addr,
+		Password: config.GetString("event.redis.password"),
+		DB:       config.GetInt("event.redis.db"),
+	}
+
+	// 发布者与订阅者关闭时都会关闭各自的客户端，因此分别创建
+	publisher, err := redisstream.NewPublisher(redisstream.PublisherConfig{
+		Client:        redis.NewClient(opts),
+		DefaultMaxlen: config.GetInt64("event.redis.max_len"),
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("创建 Redis 发布者失败：%w", err)
+	}
+	subscriber, err := redisstream.NewSubscriber(redisstream.SubscriberConfig{
+		Client:        redis.NewClient(opts),
+		ConsumerGroup: config.GetString("event.redis.consumer_group"),
+	}, logger)
+	if err != nil {
+		_ = publisher.Close()
+		return nil, fmt.Errorf("创建 Redis 订阅者失败：%w", err)
+	}
+
+	return &redisBus{publisher: publisher, subscriber: subscriber, logger: logger}, nil
+}
+
+// Publish 同步发布消息，返回时消息已写入 Stream。
+func (b *redisBus) Publish(topic string, msg ...*EventMessage) error {
+	msgWatermill := make([]*message.Message, len(msg))
+	for i, m := range msg {
+		msgWatermill[i] = m.msg
+		b.logger.Trace("发布事件消息", messageLogFields(topic, m.msg))
+	}
+	return b.publisher.Publish(topic, msgWatermill...)
+}
+
+// Subscribe 订阅主题；消息需 Ack 后才会从待确认列表移除，Nack 的消息将被重新投递。
+func (b *redisBus) Subscribe(ctx context.Context, topic string) (<-chan *EventMessage, error) {
+	ch, err := b.subscriber.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	msgCh := make(chan *EventMessage)
+	go func() {
+		defer close(msgCh)
+		for msg := range ch {
+			b.logger.Trace("接收事件消息", messageLogFields(topic, msg))
+			msgCh <- &EventMessage{msg: msg}
+		}
+	}()
+	return msgCh, nil
+}
+
+// close 先关闭发布者，再关闭订阅者（结束所有订阅并关闭客户端）。
+func (b *redisBus) close() error {
+	return errors.Join(b.publisher.Close(), b.subscriber.Close())
+}
@@ -1,32 +1,54 @@
 package abe
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/goccy/go-yaml"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/spf13/viper"
 	"golang.org/x/text/language"
 )
 
-// newI18nBundle 初始化并加载 YAML 消息文件，仅在启动阶段调用
+// newI18nBundle 初始化并加载 YAML / JSON 消息文件，仅在启动阶段调用
+// 加载失败的文件记录告警后跳过；运行期重新加载见 Engine.ReloadI18n
 func newI18nBundle(config *viper.Viper, logger *slog.Logger) *i18n.Bundle {
 	if config == nil {
 		return nil
 	}
+	bundle, err := loadI18nBundle(config, logger)
+	if bundle == nil {
+		panic(err)
+	}
+	return bundle
+}
 
+// loadI18nBundle 按 i18n.default_language 与 i18n.message_paths 构建消息包
+// 目录按配置顺序加载，目录内按文件名排序加载，后加载的同名消息覆盖先加载的（与文件格式无关）
+// 目录不可读时记录告警；默认语言无效时返回 nil，否则返回的错误汇总了解析失败的消息文件
+func loadI18nBundle(config *viper.Viper, logger *slog.Logger) (*i18n.Bundle, error) {
 	base := config.GetString("i18n.default_language")
 	if base == "" {
 		base = "en"
 	}
-	bundle := i18n.NewBundle(language.MustParse(base))
+	tag, err := language.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("i18n.default_language 无效：%w", err)
+	}
+	bundle := i18n.NewBundle(tag)
 	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
 
-	paths := config.GetStringSlice("i18n.message_paths")
-	for _, dir := range paths {
+	var errs []error
+	for _, dir := range config.GetStringSlice("i18n.message_paths") {
 		if dir == "" {
 			continue
 		}
@@ -38,15 +60,12 @@ func newI18nBundle(config *viper.Viper, logger *slog.Logger) *i18n.Bundle {
 			continue
 		}
 		for _, ent := range entries {
-			if ent.IsDir() {
-				continue
-			}
-			name := ent.Name()
-			if !strings.HasPrefix(name, "active.") || !strings.HasSuffix(name, ".yaml") {
+			if ent.IsDir() || !isI18nMessageFile(ent.Name()) {
 				continue
 			}
-			fp := filepath.Join(dir, name)
+			fp := filepath.Join(dir, ent.Name())
 			if _, err := bundle.LoadMessageFile(fp); err != nil {
+				errs = append(errs, fmt.Errorf("加载翻译文件 %s 失败：%w", fp, err))
 				if logger != nil {
 					logger.Warn("加载翻译文件失败", "file", fp, "error", err)
 				}
@@ -58,5 +77,111 @@ func newI18nBundle(config *viper.Viper, logger *slog.Logger) *i18n.Bundle {
 		}
 	}
 
-	return bundle
+	return bundle, errors.Join(errs...)
+}
+
+// isI18nMessageFile 判断文件名是否为消息文件（active.<lang>.yaml 或 active.<lang>.json）
+func isI18nMessageFile(name string) bool {
+	return strings.HasPrefix(name, "active.") && (strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".json"))
+}
+
+// currentI18nBundle 返回当前生效的消息包（ReloadI18n 替换后为新消息包）
+func (e *Engine) currentI18nBundle() *i18n.Bundle {
+	if b := e.i18nReloaded.Load(); b != nil {
+		return b
+	}
+	return e.i18nBundle
+}
+
+// ReloadI18n 重新加载 i18n.message_paths 下的消息文件并原子替换消息包
+// 已创建 Localizer 的进行中请求继续使用旧消息包，之后的请求使用新消息包；
+// 任一消息文件解析失败时保留当前消息包并返回错误
+//
+// 使用示例:
+//
+//	if err := engine.ReloadI18n(); err != nil {
+//	    log.Printf("翻译重新加载失败：%v", err)
+//	}
+func (e *Engine) ReloadI18n() error {
+	bundle, err := loadI18nBundle(e.config, e.logger)
+	if err != nil {
+		return err
+	}
+	e.i18nReloaded.Store(bundle)
+	return nil
+}
+
+// watchI18n 配置中的 i18n.message_paths / i18n.default_language 变更时重新加载消息包；
+// i18n.watch=true 时另外监听消息目录，文件变更按 config.watch_debounce 合并后重新加载
+// 监听的目录在启动时确定，运行期新增的目录需重启后生效
+func (e *Engine) watchI18n() {
+	reload := func(reason string) {
+		if err := e.ReloadI18n(); err != nil {
+			e.logger.Error("重新加载翻译文件失败，继续使用当前翻译", "reason", reason, "error", err)
+			return
+		}
+		e.logger.Info("已重新加载翻译文件", "reason", reason)
+	}
+
+	e.OnConfigChange(func(key string) {
+		if key == "i18n.message_paths" || key == "i18n.default_language" {
+			reload(key)
+		}
+	})
+
+	if !e.config.GetBool("i18n.watch") {
+		return
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		e.logger.Error("创建翻译目录监听失败", "error", err)
+		return
+	}
+	var dirs []string
+	for _, dir := range e.config.GetStringSlice("i18n.message_paths") {
+		if dir == "" {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			e.logger.Warn("监听翻译目录失败", "dir", dir, "error", err)
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		_ = watcher.Close()
+		return
+	}
+	e.OnShutdown(func(context.Context) error {
+		return watcher.Close()
+	})
+
+	debounce := e.config.GetDuration("config.watch_debounce")
+	if debounce <= 0 {
+		debounce = defaultConfigWatchDebounce
+	}
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !isI18nMessageFile(filepath.Base(ev.Name)) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(debounce, func() { reload(ev.Name) })
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				e.logger.Warn("翻译目录监听出错", "error", err)
+			}
+		}
+	}()
+	e.logger.Info("已启用翻译文件热更新", "dirs", dirs, "debounce", debounce)
 }
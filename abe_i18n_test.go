@@ -0,0 +1,41 @@
+package abe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/spf13/viper"
+)
+
+func TestLoadI18nBundleJSONOverridesYAML(t *testing.T) {
+	base, override := t.TempDir(), t.TempDir()
+	writeFile := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("写入翻译文件失败: %v", err)
+		}
+	}
+	writeFile(base, "active.zh.yaml", "greeting: 你好\nfarewell: 再见\n")
+	writeFile(override, "active.zh.json", `{"greeting": "您好"}`)
+
+	cfg := viper.New()
+	cfg.Set("i18n.default_language", "zh")
+	cfg.Set("i18n.message_paths", []string{base, override})
+	bundle, err := loadI18nBundle(cfg, nil)
+	if err != nil {
+		t.Fatalf("加载消息包失败: %v", err)
+	}
+
+	loc := i18n.NewLocalizer(bundle, "zh")
+	for id, want := range map[string]string{"greeting": "您好", "farewell": "再见"} {
+		got, err := loc.Localize(&i18n.LocalizeConfig{MessageID: id})
+		if err != nil {
+			t.Fatalf("翻译 %s 失败: %v", id, err)
+		}
+		if got != want {
+			t.Errorf("%s 期望 %q，实际 %q", id, want, got)
+		}
+	}
+}
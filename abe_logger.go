@@ -27,10 +27,36 @@ type LogConfig struct {
 	} `mapstructure:"file"` // 文件日志配置，仅在 type 为 "file" 时有效
 }
 
+// newLogLevel 创建可动态调整的日志级别，配置热更新时通过 Engine.reloadLogLevel 重新计算
+func newLogLevel(cfg *viper.Viper) *slog.LevelVar {
+	level, err := resolveLogLevel(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("解析日志级别失败: %v", err))
+	}
+	lv := new(slog.LevelVar)
+	lv.Set(level)
+	return lv
+}
+
+// resolveLogLevel 按当前配置（含运行环境默认值）解析日志级别
+func resolveLogLevel(cfg *viper.Viper) (slog.Level, error) {
+	var lc LogConfig
+	if err := cfg.UnmarshalKey("logger", &lc); err != nil {
+		return slog.LevelInfo, fmt.Errorf("解析日志配置失败: %w", err)
+	}
+	if cfg.GetBool("app.debug") {
+		lc.Level = "debug"
+	}
+	if lc.Level == "" {
+		lc.Level = "info"
+	}
+	return LevelFromString(lc.Level)
+}
+
 // newLogger 获取日志记录器
 // 根据配置初始化日志系统
 // 支持控制台和文件日志输出，根据环境自动配置日志级别和格式
-func newLogger(cfg *viper.Viper) *slog.Logger {
+func newLogger(cfg *viper.Viper, level *slog.LevelVar) *slog.Logger {
 	var lc LogConfig
 	err := cfg.UnmarshalKey("logger", &lc)
 	if err != nil {
@@ -39,12 +65,6 @@ func newLogger(cfg *viper.Viper) *slog.Logger {
 	// 根据环境设置默认配置
 	setDefaultLogConfig(cfg, &lc)
 
-	// 解析日志级别
-	level, err := LevelFromString(lc.Level)
-	if err != nil {
-		panic(fmt.Sprintf("解析日志级别失败: %v", err))
-	}
-
 	// 确定日志输出目标
 	var logWriter io.Writer
 	if lc.Type == "file" && lc.File.Path != "" {
@@ -18,12 +18,15 @@ type MiddlewareManager struct {
 	globals []gin.HandlerFunc
 	shared  map[string]gin.HandlerFunc
 	groups  map[string][]gin.HandlerFunc
+	// groupShared 记录各分组已包含的共享中间件名称，避免同一共享中间件被重复追加而在请求中执行两次
+	groupShared map[string]map[string]struct{}
 }
 
 func newMiddlewareManager() *MiddlewareManager {
 	return &MiddlewareManager{
-		shared: make(map[string]gin.HandlerFunc),
-		groups: make(map[string][]gin.HandlerFunc),
+		shared:      make(map[string]gin.HandlerFunc),
+		groups:      make(map[string][]gin.HandlerFunc),
+		groupShared: make(map[string]map[string]struct{}),
 	}
 }
 
@@ -131,14 +134,20 @@ func (m *MiddlewareManager) CreateGroupFromShared(name string, sharedNames ...st
 		return ErrDuplicateName
 	}
 	handlers := make([]gin.HandlerFunc, 0, len(sharedNames))
+	names := make(map[string]struct{}, len(sharedNames))
 	for _, n := range sharedNames {
 		h, ok := m.shared[n]
 		if !ok {
 			return ErrNotFound
 		}
+		if _, dup := names[n]; dup {
+			continue
+		}
+		names[n] = struct{}{}
 		handlers = append(handlers, h)
 	}
 	m.groups[name] = handlers
+	m.groupShared[name] = names
 	return nil
 }
 
@@ -156,7 +165,14 @@ func (m *MiddlewareManager) CreateGroupFromGroups(name string, groupNames ...str
 	if err != nil {
 		return err
 	}
+	names := make(map[string]struct{})
+	for _, gn := range groupNames {
+		for n := range m.groupShared[gn] {
+			names[n] = struct{}{}
+		}
+	}
 	m.groups[name] = handlers
+	m.groupShared[name] = names
 	return nil
 }
 
@@ -206,6 +222,7 @@ func (m *MiddlewareManager) UpdateGroup(name string, handlers ...gin.HandlerFunc
 	}
 	cp := append([]gin.HandlerFunc(nil), handlers...)
 	m.groups[name] = cp
+	delete(m.groupShared, name)
 	return nil
 }
 
@@ -233,14 +250,24 @@ func (m *MiddlewareManager) AppendSharedToGroup(name string, sharedNames ...stri
 	if !ok {
 		return ErrNotFound
 	}
+	names := make(map[string]struct{}, len(m.groupShared[name])+len(sharedNames))
+	for n := range m.groupShared[name] {
+		names[n] = struct{}{}
+	}
 	for _, sn := range sharedNames {
 		h, ok := m.shared[sn]
 		if !ok {
 			return ErrNotFound
 		}
+		// 分组中已存在同名共享中间件时跳过
+		if _, dup := names[sn]; dup {
+			continue
+		}
+		names[sn] = struct{}{}
 		gs = append(gs, h)
 	}
 	m.groups[name] = gs
+	m.groupShared[name] = names
 	return nil
 }
 
@@ -251,6 +278,7 @@ func (m *MiddlewareManager) RemoveGroup(name string) bool {
 		return false
 	}
 	delete(m.groups, name)
+	delete(m.groupShared, name)
 	return true
 }
 
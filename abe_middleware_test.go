@@ -0,0 +1,32 @@
+package abe
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAppendSharedToGroupSkipsDuplicates(t *testing.T) {
+	m := newMiddlewareManager()
+	if err := m.RegisterShared("auth", func(*gin.Context) {}); err != nil {
+		t.Fatalf("注册共享中间件失败: %v", err)
+	}
+	if err := m.CreateGroup("api"); err != nil {
+		t.Fatalf("创建分组失败: %v", err)
+	}
+
+	if err := m.AppendSharedToGroup("api", "auth"); err != nil {
+		t.Fatalf("首次追加共享中间件失败: %v", err)
+	}
+	if err := m.AppendSharedToGroup("api", "auth", "auth"); err != nil {
+		t.Fatalf("重复追加共享中间件失败: %v", err)
+	}
+
+	chain, ok := m.GetGroup("api")
+	if !ok {
+		t.Fatal("分组不存在")
+	}
+	if len(chain) != 1 {
+		t.Fatalf("同名共享中间件应只出现一次，实际 %d 个", len(chain))
+	}
+}
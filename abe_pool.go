@@ -1,10 +1,16 @@
 package abe
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/panjf2000/ants/v2"
 	"github.com/spf13/viper"
 )
@@ -107,7 +113,7 @@ func initializePool(config PoolConfig, logger *slog.Logger) (*ants.Pool, error)
 		ants.WithNonblocking(config.Nonblocking),
 		ants.WithLogger(logAdapter),
 		ants.WithPanicHandler(func(i any) {
-			logger.Error("协程池任务发生 panic", "error", i)
+			logger.Error("协程池任务发生 panic", "error", i, "stack", string(debug.Stack()))
 		}),
 	}
 
@@ -146,7 +152,7 @@ func newPoolWithFunc(fn func(any), size int, logger *slog.Logger) (*ants.PoolWit
 		ants.WithNonblocking(cfg.Nonblocking),
 		ants.WithLogger(logAdapter),
 		ants.WithPanicHandler(func(i any) {
-			logger.Error("函数协程池任务发生 panic", "error", i)
+			logger.Error("函数协程池任务发生 panic", "error", i, "stack", string(debug.Stack()))
 		}),
 	}
 
@@ -158,3 +164,134 @@ func newPoolWithFunc(fn func(any), size int, logger *slog.Logger) (*ants.PoolWit
 
 	return pool, nil
 }
+
+// PoolStats 协程池运行指标
+type PoolStats struct {
+	Running int `json:"running"` // 正在运行的协程数
+	Free    int `json:"free"`    // 空闲可用的协程数
+	Cap     int `json:"cap"`     // 协程池容量
+	Waiting int `json:"waiting"` // 阻塞等待提交的任务数
+}
+
+// PoolStats 返回引擎协程池的当前运行指标
+// Waiting 接近 pool.max_blocking_tasks 时，后续提交将被拒绝，可据此提前告警
+func (e *Engine) PoolStats() PoolStats {
+	if e.pool == nil {
+		return PoolStats{}
+	}
+	return PoolStats{
+		Running: e.pool.Running(),
+		Free:    e.pool.Free(),
+		Cap:     e.pool.Cap(),
+		Waiting: e.pool.Waiting(),
+	}
+}
+
+// PoolStatsHandler 协程池指标查询处理器，以 JSON 返回 PoolStats
+//
+// 使用示例:
+//
+//	rg.GET("/debug/pool", abe.PoolStatsHandler(e))
+func PoolStatsHandler(e *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, Response[PoolStats]{Msg: "ok", Data: e.PoolStats()})
+	}
+}
+
+// 上下文感知提交的任务状态
+const (
+	taskPending int32 = iota
+	taskRunning
+	taskCancelled
+)
+
+// SubmitTask 向引擎协程池提交任务，支持通过上下文取消阻塞中的提交
+//
+// 参数:
+//   - ctx: 控制提交等待的上下文
+//   - fn: 任务函数
+//
+// 返回:
+//   - error: 提交成功返回 nil；上下文在任务开始执行前被取消时返回 ctx.Err()，且任务不会再执行；
+//     协程池拒绝（如超过 max_blocking_tasks 或已关闭）时返回 ants 的错误
+func (e *Engine) SubmitTask(ctx context.Context, fn func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var state atomic.Int32
+	task := func() {
+		if state.CompareAndSwap(taskPending, taskRunning) {
+			fn()
+		}
+	}
+
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- e.pool.Submit(task)
+	}()
+
+	select {
+	case err := <-submitted:
+		return err
+	case <-ctx.Done():
+		// 任务尚未开始执行时将其标记为取消；若已开始执行则视为提交成功
+		if state.CompareAndSwap(taskPending, taskCancelled) {
+			return ctx.Err()
+		}
+		return nil
+	}
+}
+
+// SubmitWait 向引擎协程池提交任务并阻塞等待其执行完成
+//
+// 参数:
+//   - fn: 任务函数，panic 会被恢复并以错误返回
+//
+// 返回:
+//   - error: 提交失败或任务 panic 时返回错误
+func (e *Engine) SubmitWait(fn func()) error {
+	var wg sync.WaitGroup
+	var panicked any
+	wg.Add(1)
+	err := e.pool.Submit(func() {
+		defer wg.Done()
+		defer func() {
+			panicked = recover()
+		}()
+		fn()
+	})
+	if err != nil {
+		return err
+	}
+	wg.Wait()
+	if panicked != nil {
+		return fmt.Errorf("协程池任务发生 panic: %v", panicked)
+	}
+	return nil
+}
+
+// SubmitNamed 向引擎协程池提交具名任务，任务 panic 时记录任务名、调用栈与附加属性，便于定位来源
+//
+// 参数:
+//   - name: 任务名（如 "order.sync"），写入日志的 task 字段
+//   - fn: 任务函数，panic 会被恢复并记录，不会再交由协程池的 panic 处理器
+//   - attrs: 附加的日志属性键值对（如 "request_id", abe.GetRequestID(ctx)），用于关联请求
+//
+// 返回:
+//   - error: 协程池拒绝提交时返回 ants 的错误
+//
+// 使用示例:
+//
+//	_ = e.SubmitNamed("report.export", func() { export(id) }, "request_id", abe.GetRequestID(ctx))
+func (e *Engine) SubmitNamed(name string, fn func(), attrs ...any) error {
+	return e.pool.Submit(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				args := append([]any{"task", name, "error", r, "stack", string(debug.Stack())}, attrs...)
+				e.logger.Error("协程池任务发生 panic", args...)
+			}
+		}()
+		fn()
+	})
+}
@@ -0,0 +1,72 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+func TestSubmitTaskCancelledWhilePoolSaturated(t *testing.T) {
+	pool, err := ants.NewPool(1)
+	if err != nil {
+		t.Fatalf("创建协程池失败: %v", err)
+	}
+	defer pool.Release()
+	e := &Engine{pool: pool}
+
+	// 占满唯一的 worker，使后续提交阻塞
+	release := make(chan struct{})
+	running := make(chan struct{})
+	if err := pool.Submit(func() { close(running); <-release }); err != nil {
+		t.Fatalf("提交占位任务失败: %v", err)
+	}
+	<-running
+
+	var ran atomic.Bool
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := e.SubmitTask(ctx, func() { ran.Store(true) }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望 context.DeadlineExceeded，实际 %v", err)
+	}
+
+	// 释放 worker 后，已取消的任务即使被协程池调度也不应执行
+	close(release)
+	done := make(chan struct{})
+	if err := e.SubmitTask(context.Background(), func() { close(done) }); err != nil {
+		t.Fatalf("释放后提交任务失败: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("释放后提交的任务未执行")
+	}
+	if ran.Load() {
+		t.Fatal("已取消的任务不应执行")
+	}
+}
+
+func TestSubmitTaskWithCancelledContext(t *testing.T) {
+	pool, err := ants.NewPool(1)
+	if err != nil {
+		t.Fatalf("创建协程池失败: %v", err)
+	}
+	defer pool.Release()
+	e := &Engine{pool: pool}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var ran atomic.Bool
+	if err := e.SubmitTask(ctx, func() { ran.Store(true) }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("期望 context.Canceled，实际 %v", err)
+	}
+	if err := e.SubmitWait(func() {}); err != nil {
+		t.Fatalf("SubmitWait 失败: %v", err)
+	}
+	if ran.Load() {
+		t.Fatal("上下文已取消时任务不应提交执行")
+	}
+}
@@ -1,7 +1,10 @@
 package abe
 
 import (
+	"fmt"
 	"log/slog"
+	"net/http"
+	"runtime/debug"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,24 +19,28 @@ func newRouter(cfg *viper.Viper, logger *slog.Logger) *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	router := gin.New()
-	router.Use(ginRecovery(logger))
+	router.Use(ginRecovery(logger, cfg.GetBool("app.debug")))
 	router.Use(ginLogger(logger))
 	return router
 }
 
 // ginLogger 是 Gin 框架的日志中间件
 // 将 Gin 的日志输出重定向到 core.Logger
-// 使用结构化日志记录 HTTP 请求信息
+// 使用结构化日志记录 HTTP 请求信息，并合并处理器通过 AddLogField 追加的业务字段；
+// 存在请求级日志记录器（见 LoggerContextMiddleware）时使用之，使访问日志携带 request_id
 func ginLogger(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 开始时间
 		start := time.Now()
-		// 请求路径
+		// 请求路径（原始路径，含路径参数的实际值）
 		path := c.Request.URL.Path
 		// 请求方法
 		method := c.Request.Method
 		// 客户端 IP
 		clientIP := c.ClientIP()
+		// 业务字段累加器（见 AddLogField），以指针共享，处理器在复制的上下文中追加同样生效
+		fields := &logFields{}
+		c.Set(contextKeyLogFields, fields)
 
 		// 处理请求
 		c.Next()
@@ -55,28 +62,31 @@ func ginLogger(logger *slog.Logger) gin.HandlerFunc {
 			logLevel = slog.LevelError
 		}
 
-		// 使用结构化日志记录请求信息
-		logger.LogAttrs(
-			c.Request.Context(),
-			logLevel,
-			"HTTP 请求",
+		// 使用结构化日志记录请求信息，处理器追加的业务字段置于其后
+		attrs := []slog.Attr{
 			slog.String("client_ip", clientIP),
 			slog.String("method", method),
 			slog.String("path", path),
+			slog.String("route", RouteTemplate(c)),
 			slog.Int("status_code", statusCode),
 			slog.Duration("latency", latency),
 			slog.String("error", errorMessage),
 			slog.String("user_agent", c.Request.UserAgent()),
-		)
+		}
+		attrs = append(attrs, fields.snapshot()...)
+		requestLogger(c, logger).LogAttrs(c.Request.Context(), logLevel, "HTTP 请求", attrs...)
 	}
 }
 
 // ginRecovery 是 Gin 框架的恢复中间件
-// 捕获 panic 并使用 core.Logger 记录错误信息
-func ginRecovery(logger *slog.Logger) gin.HandlerFunc {
+// 捕获 panic 并使用 core.Logger 记录错误信息与调用栈（在恢复处捕获，保留 panic 发生位置）
+// 调试模式（app.debug）下响应中同时返回错误与调用栈，发布模式下仅记录日志
+func ginRecovery(logger *slog.Logger, debugMode bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
+				stack := debug.Stack()
+
 				// 记录 panic 错误
 				logger.LogAttrs(
 					c.Request.Context(),
@@ -86,9 +96,18 @@ func ginRecovery(logger *slog.Logger) gin.HandlerFunc {
 					slog.String("method", c.Request.Method),
 					slog.String("path", c.Request.URL.Path),
 					slog.String("client_ip", c.ClientIP()),
+					slog.String("stack", string(stack)),
 				)
 
 				// 返回 500 错误
+				if debugMode {
+					c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{
+						Code: ErrorCode(http.StatusInternalServerError),
+						Msg:  "内部服务器错误",
+						Data: gin.H{"error": fmt.Sprint(err), "stack": stackLines(stack)},
+					})
+					return
+				}
 				c.AbortWithStatus(500)
 			}
 		}()
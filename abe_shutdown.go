@@ -0,0 +1,70 @@
+package abe
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ShutdownFunc 停机排空回调，ctx 的截止时间受 server.shutdown_timeout 约束
+type ShutdownFunc func(ctx context.Context) error
+
+// OnShutdown 注册停机排空回调，用于刷新缓冲、关闭外部客户端等
+// 回调在 HTTP 服务器停止接收请求之后、事件总线关闭与协程池释放之前按注册顺序依次执行；
+// 全部回调共享 server.shutdown_timeout 时长，单个回调失败或 panic 只记录日志，不影响后续回调
+//
+// 使用示例:
+//
+//	engine.OnShutdown(func(ctx context.Context) error {
+//	    return producer.Flush(ctx)
+//	})
+func (e *Engine) OnShutdown(fn ShutdownFunc) {
+	e.shutdownMu.Lock()
+	defer e.shutdownMu.Unlock()
+	e.shutdownHooks = append(e.shutdownHooks, fn)
+}
+
+// shutdownTimeout 优雅停机超时时长（server.shutdown_timeout），未配置时使用默认值
+func (e *Engine) shutdownTimeout() time.Duration {
+	timeout := e.config.GetDuration("server.shutdown_timeout")
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	return timeout
+}
+
+// runShutdownHooks 按注册顺序执行停机排空回调并记录每个回调的耗时
+func (e *Engine) runShutdownHooks() {
+	e.shutdownMu.Lock()
+	hooks := append([]ShutdownFunc(nil), e.shutdownHooks...)
+	e.shutdownMu.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.shutdownTimeout())
+	defer cancel()
+
+	for i, hook := range hooks {
+		start := time.Now()
+		err := callShutdownHook(ctx, hook)
+		if e.logger == nil {
+			continue
+		}
+		if err != nil {
+			e.logger.Error("停机回调执行失败", "index", i, "duration", time.Since(start), "error", err)
+		} else {
+			e.logger.Info("停机回调执行完成", "index", i, "duration", time.Since(start))
+		}
+	}
+}
+
+// callShutdownHook 调用停机回调，将 panic 转换为错误
+func callShutdownHook(ctx context.Context, hook ShutdownFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("停机回调发生 panic：%v", r)
+		}
+	}()
+	return hook(ctx)
+}
@@ -3,12 +3,17 @@ package abe
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin/binding"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/viper"
 )
 
@@ -18,6 +23,9 @@ type ValidationRule struct {
 	tag          string            // 规则标签名（如 "username"）
 	fn           validator.Func    // 验证函数
 	translations map[string]string // 翻译模板 locale -> template
+
+	callEvenIfNull bool // 字段为零值时是否仍执行验证（见 NewConditionalRule）
+	conditional    bool // 翻译模板的 {1} 是否渲染为条件描述（见 NewConditionalRule）
 }
 
 // NewValidationRule 创建自定义验证规则
@@ -87,8 +95,29 @@ func (r *ValidationRule) check() error {
 // Validator 验证器管理器，负责管理验证规则、翻译和配置
 type Validator struct {
 	instance    *validator.Validate
-	customRules map[string]*ValidationRule // 自定义规则集合
 	locale      string
+	mu          sync.RWMutex
+	customRules map[string]*ValidationRule                   // 自定义规则集合
+	translators map[string]ut.Translator                     // 已构建的翻译器 locale -> translator，后注册的规则会补注册翻译
+	structRules map[reflect.Type][]validator.StructLevelFunc // 结构体级规则（见 RegisterStructRule）
+}
+
+// fieldDisplayName 字段显示名：label > json > 字段名
+func fieldDisplayName(fld reflect.StructField) string {
+	if name := fld.Tag.Get("label"); name != "" {
+		return name
+	}
+	if jsonTag := fld.Tag.Get("json"); jsonTag != "" {
+		// 去除 ,omitempty 等
+		for i, ch := range jsonTag {
+			if ch == ',' {
+				jsonTag = jsonTag[:i]
+				break
+			}
+		}
+		return jsonTag
+	}
+	return fld.Name
 }
 
 // newValidator 创建并初始化验证器实例
@@ -112,22 +141,7 @@ func newValidator(config *viper.Viper) *Validator {
 	gv.SetTagName("validate")
 
 	// 字段标签名函数：label > json > 字段名
-	gv.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		if name := fld.Tag.Get("label"); name != "" {
-			return name
-		}
-		if jsonTag := fld.Tag.Get("json"); jsonTag != "" {
-			// 去除 ,omitempty 等
-			for i, ch := range jsonTag {
-				if ch == ',' {
-					jsonTag = jsonTag[:i]
-					break
-				}
-			}
-			return jsonTag
-		}
-		return fld.Name
-	})
+	gv.RegisterTagNameFunc(fieldDisplayName)
 
 	// 注册 abe 内置通用规则将在返回 Validator 对象后批量注册
 
@@ -135,6 +149,8 @@ func newValidator(config *viper.Viper) *Validator {
 		instance:    gv,
 		locale:      defaultLocale,
 		customRules: make(map[string]*ValidationRule),
+		translators: make(map[string]ut.Translator),
+		structRules: make(map[reflect.Type][]validator.StructLevelFunc),
 	}
 
 	// 批量注册内置规则（使用 Must 版本，初始化失败则 panic）
@@ -156,6 +172,8 @@ func (v *Validator) Locale() string {
 }
 
 // RegisterCustomRule 注册自定义验证规则
+// 可在引擎启动后调用（如插件 Init 中），规则翻译会立即注册到已构建的各语言翻译器；
+// 底层验证器的注册本身不与校验并发安全，应在使用该规则的请求到达前完成注册
 func (v *Validator) RegisterCustomRule(rule *ValidationRule) error {
 	// 验证规则完整性
 	if err := rule.check(); err != nil {
@@ -163,12 +181,22 @@ func (v *Validator) RegisterCustomRule(rule *ValidationRule) error {
 	}
 
 	// 注册验证函数到底层验证器
-	if err := v.instance.RegisterValidation(rule.tag, rule.fn); err != nil {
+	if err := v.instance.RegisterValidation(rule.tag, rule.fn, rule.callEvenIfNull); err != nil {
 		return fmt.Errorf("failed to register validation '%s': %w", rule.tag, err)
 	}
 
-	// 存储规则对象（用于后续翻译注册）
+	// 存储规则对象（用于后续翻译注册），并补注册到已构建的翻译器
+	v.mu.Lock()
 	v.customRules[rule.tag] = rule
+	translators := make(map[string]ut.Translator, len(v.translators))
+	for locale, trans := range v.translators {
+		translators[locale] = trans
+	}
+	v.mu.Unlock()
+
+	for locale, trans := range translators {
+		v.registerRuleTranslation(trans, locale, rule)
+	}
 
 	return nil
 }
@@ -180,20 +208,36 @@ func (v *Validator) MustRegisterCustomRule(rule *ValidationRule) {
 	}
 }
 
-// registerCustomRuleTranslations 注册自定义规则的翻译
-func (v *Validator) registerCustomRuleTranslations(trans ut.Translator, locale string) {
+// attachTranslator 记录已构建的翻译器并注册当前全部自定义规则的翻译
+// 之后通过 RegisterCustomRule 注册的规则也会自动注册到该翻译器
+func (v *Validator) attachTranslator(trans ut.Translator, locale string) {
+	v.mu.Lock()
+	v.translators[locale] = trans
+	rules := make([]*ValidationRule, 0, len(v.customRules))
 	for _, rule := range v.customRules {
-		template := rule.getTranslation(locale)
-		if template == "" {
-			continue
-		}
+		rules = append(rules, rule)
+	}
+	v.mu.Unlock()
 
-		// 自动检测是否需要参数
-		if rule.hasParam() {
-			v.registerTranslationWithParam(trans, rule.tag, template)
-		} else {
-			v.registerTranslation(trans, rule.tag, template)
-		}
+	for _, rule := range rules {
+		v.registerRuleTranslation(trans, locale, rule)
+	}
+}
+
+// registerRuleTranslation 注册单条自定义规则在指定语言下的翻译
+func (v *Validator) registerRuleTranslation(trans ut.Translator, locale string, rule *ValidationRule) {
+	template := rule.getTranslation(locale)
+	if template == "" {
+		return
+	}
+
+	// 条件规则渲染条件描述；其他规则自动检测是否需要参数
+	if rule.conditional {
+		v.registerConditionTranslation(trans, rule.tag, template, locale, conditionPairs)
+	} else if rule.hasParam() {
+		v.registerTranslationWithParam(trans, rule.tag, template)
+	} else {
+		v.registerTranslation(trans, rule.tag, template)
 	}
 }
 
@@ -310,6 +354,78 @@ func validateStrongPassword(fl validator.FieldLevel) bool {
 	return hasUpper && hasLower && hasDigit
 }
 
+// validateBankCard 验证银行卡号：12-19位数字且通过 Luhn 校验
+func validateBankCard(fl validator.FieldLevel) bool {
+	val := fl.Field().String()
+	if len(val) < 12 || len(val) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(val) - 1; i >= 0; i-- {
+		ch := val[i]
+		if ch < '0' || ch > '9' {
+			return false
+		}
+		d := int(ch - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// validateURLHTTP 验证 URL：仅允许 http/https 协议且主机非空
+func validateURLHTTP(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// semverPattern 语义化版本 2.0.0 规范（https://semver.org），不接受 v 前缀
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// validateSemver 验证语义化版本号，如 1.2.3、1.0.0-rc.1+build.5
+func validateSemver(fl validator.FieldLevel) bool {
+	return semverPattern.MatchString(fl.Field().String())
+}
+
+// cronExprParser 与 CronManager 一致的表达式解析器：秒 分 时 日 月 周，支持 @every 等描述符
+var cronExprParser = cron.NewParser(
+	cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// validateCronExpr 验证 cron 表达式可被 CronManager 解析
+func validateCronExpr(fl validator.FieldLevel) bool {
+	_, err := cronExprParser.Parse(fl.Field().String())
+	return err == nil
+}
+
+// validatePort 验证端口号：1-65535，支持整数与数字字符串
+func validatePort(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() >= 1 && field.Int() <= 65535
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint() >= 1 && field.Uint() <= 65535
+	case reflect.String:
+		n, err := strconv.Atoi(field.String())
+		return err == nil && n >= 1 && n <= 65535
+	default:
+		return false
+	}
+}
+
 // --- 内置验证规则定义（导出，可供其他包使用） ---
 
 var (
@@ -337,6 +453,31 @@ var (
 	BuiltinRuleStrongPassword = NewValidationRule("strong_password", validateStrongPassword).
 					WithZhTranslation("{0}必须至少8位，且包含大小写字母和数字").
 					WithEnTranslation("{0} must be at least 8 characters with uppercase, lowercase and digits")
+
+	// BuiltinRuleBankCard 银行卡号验证规则（Luhn 校验）
+	BuiltinRuleBankCard = NewValidationRule("bank_card", validateBankCard).
+				WithZhTranslation("{0}必须是有效的银行卡号").
+				WithEnTranslation("{0} must be a valid bank card number")
+
+	// BuiltinRuleURLHTTP HTTP/HTTPS 地址验证规则
+	BuiltinRuleURLHTTP = NewValidationRule("url_http", validateURLHTTP).
+				WithZhTranslation("{0}必须是有效的 http 或 https 地址").
+				WithEnTranslation("{0} must be a valid http or https URL")
+
+	// BuiltinRuleSemver 语义化版本号验证规则
+	BuiltinRuleSemver = NewValidationRule("semver", validateSemver).
+				WithZhTranslation("{0}必须是有效的语义化版本号").
+				WithEnTranslation("{0} must be a valid semantic version")
+
+	// BuiltinRuleCronExpr cron 表达式验证规则（含秒字段）
+	BuiltinRuleCronExpr = NewValidationRule("cron_expr", validateCronExpr).
+				WithZhTranslation("{0}必须是有效的 cron 表达式").
+				WithEnTranslation("{0} must be a valid cron expression")
+
+	// BuiltinRulePort 端口号验证规则
+	BuiltinRulePort = NewValidationRule("port", validatePort).
+			WithZhTranslation("{0}必须是1-65535之间的端口号").
+			WithEnTranslation("{0} must be a port number between 1 and 65535")
 )
 
 // builtinRules 返回所有内置规则
@@ -347,5 +488,10 @@ func builtinRules() []*ValidationRule {
 		BuiltinRuleUsername,
 		BuiltinRuleChineseName,
 		BuiltinRuleStrongPassword,
+		BuiltinRuleBankCard,
+		BuiltinRuleURLHTTP,
+		BuiltinRuleSemver,
+		BuiltinRuleCronExpr,
+		BuiltinRulePort,
 	}
 }
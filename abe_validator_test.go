@@ -0,0 +1,48 @@
+package abe
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+func TestRegisterCustomRuleAfterStartupTranslates(t *testing.T) {
+	cfg := viper.New()
+	e := &Engine{
+		config:    cfg,
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		validator: newValidator(cfg),
+	}
+	// 启动阶段：构建各语言翻译器
+	validationTranslatorMiddleware(e)
+
+	rule := NewValidationRule("test_even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}).WithZhTranslation("{0}必须为偶数").WithEnTranslation("{0} must be even")
+	if err := e.validator.RegisterCustomRule(rule); err != nil {
+		t.Fatalf("注册自定义规则失败: %v", err)
+	}
+
+	type form struct {
+		Count int `json:"count" label:"数量" validate:"test_even"`
+	}
+	err := e.validator.Instance().Struct(form{Count: 3})
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 1 {
+		t.Fatalf("期望一个校验错误，实际 %v", err)
+	}
+
+	for locale, want := range map[string]string{"zh": "数量必须为偶数", "en": "数量 must be even"} {
+		trans := e.validator.translators[locale]
+		if trans == nil {
+			t.Fatalf("未构建 %s 翻译器", locale)
+		}
+		if got := verrs[0].Translate(trans); got != want {
+			t.Errorf("%s 翻译期望 %q，实际 %q", locale, want, got)
+		}
+	}
+}
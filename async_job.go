@@ -0,0 +1,326 @@
+package abe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ErrAsyncJobNotFound 异步任务不存在
+var ErrAsyncJobNotFound = errors.New("async job not found")
+
+// 异步任务存储默认值
+const (
+	defaultAsyncJobCapacity = 10000        // 内存存储默认容量
+	defaultAsyncJobTable    = "async_jobs" // 数据库存储默认表名
+)
+
+// AsyncJobStatus 异步任务状态
+type AsyncJobStatus string
+
+const (
+	AsyncJobPending AsyncJobStatus = "pending" // 已受理，等待执行
+	AsyncJobRunning AsyncJobStatus = "running" // 执行中
+	AsyncJobDone    AsyncJobStatus = "done"    // 执行成功
+	AsyncJobFailed  AsyncJobStatus = "failed"  // 执行失败
+)
+
+// AsyncJob 异步任务记录
+type AsyncJob struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`             // 任务类型（如 "report.export"）
+	Status    AsyncJobStatus  `json:"status"`           // 当前状态
+	Result    json.RawMessage `json:"result,omitempty"` // 成功时的结果（JSON）
+	Error     string          `json:"error,omitempty"`  // 失败时的错误信息
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// AsyncJobStore 异步任务存储接口
+// 框架提供内存（默认）与数据库两种实现，可通过 Engine.SetAsyncJobStore 替换；多实例部署应使用数据库存储
+type AsyncJobStore interface {
+	// Save 保存任务记录，ID 相同时覆盖
+	Save(ctx context.Context, job AsyncJob) error
+	// Get 获取任务记录，不存在时返回 ErrAsyncJobNotFound
+	Get(ctx context.Context, id string) (AsyncJob, error)
+}
+
+// AsyncJobFunc 异步任务函数，返回值序列化为 JSON 后作为任务结果
+type AsyncJobFunc func(ctx context.Context) (any, error)
+
+// newAsyncJobStore 按 async_job.store 配置创建异步任务存储
+// - memory（默认）：进程内存储，容量由 async_job.capacity 控制
+// - database：使用 GORM 持久化到 async_job.table 表
+func newAsyncJobStore(e *Engine) AsyncJobStore {
+	cfg := e.Config()
+	switch cfg.GetString("async_job.store") {
+	case "database":
+		table := cfg.GetString("async_job.table")
+		if table == "" {
+			table = defaultAsyncJobTable
+		}
+		store, err := NewGormAsyncJobStore(e.DB(), table)
+		if err != nil {
+			e.Logger().Error("初始化数据库异步任务存储失败，回退为内存存储", "table", table, "error", err)
+			return NewMemoryAsyncJobStore(cfg.GetInt("async_job.capacity"))
+		}
+		return store
+	default:
+		return NewMemoryAsyncJobStore(cfg.GetInt("async_job.capacity"))
+	}
+}
+
+// AsyncJobs 异步任务存储（懒加载）
+func (e *Engine) AsyncJobs() AsyncJobStore {
+	e.asyncJobsOnce.Do(func() {
+		if e.asyncJobs == nil {
+			e.asyncJobs = newAsyncJobStore(e)
+		}
+	})
+	return e.asyncJobs
+}
+
+// SetAsyncJobStore 替换异步任务存储实现，应在引擎启动前调用
+func (e *Engine) SetAsyncJobStore(store AsyncJobStore) {
+	e.asyncJobsOnce.Do(func() {})
+	e.asyncJobs = store
+}
+
+// AsyncJobTracker 异步任务跟踪器
+// 受理请求时创建任务并立即返回 202 与状态查询地址，任务在协程池中执行并更新状态，客户端轮询状态接口获取进度与结果
+//
+// 使用示例:
+//
+//	jobs := abe.NewAsyncJobTracker(e, "/api/jobs")
+//	rg.GET("/jobs/:id", jobs.StatusHandler())
+//	rg.POST("/reports", func(ctx *gin.Context) {
+//	    jobs.Accept(ctx, "report.export", func(ctx context.Context) (any, error) {
+//	        return exportReport(ctx)
+//	    })
+//	})
+type AsyncJobTracker struct {
+	engine     *Engine
+	statusPath string
+}
+
+// NewAsyncJobTracker 创建异步任务跟踪器
+//
+// 参数:
+//   - statusPath: 状态接口的完整路径前缀，状态地址为 statusPath + "/" + 任务 ID
+func NewAsyncJobTracker(e *Engine, statusPath string) *AsyncJobTracker {
+	return &AsyncJobTracker{engine: e, statusPath: strings.TrimSuffix(statusPath, "/")}
+}
+
+// StatusURL 返回任务的状态查询地址
+func (t *AsyncJobTracker) StatusURL(id string) string {
+	return t.statusPath + "/" + id
+}
+
+// Submit 创建任务并提交到协程池执行，返回处于 pending 状态的任务记录
+// 任务函数使用独立于请求的上下文执行，panic 视为执行失败
+func (t *AsyncJobTracker) Submit(ctx context.Context, kind string, fn AsyncJobFunc) (AsyncJob, error) {
+	store := t.engine.AsyncJobs()
+	now := t.engine.Clock().Now()
+	job := AsyncJob{ID: watermill.NewUUID(), Kind: kind, Status: AsyncJobPending, CreatedAt: now, UpdatedAt: now}
+	if err := store.Save(ctx, job); err != nil {
+		return AsyncJob{}, fmt.Errorf("保存异步任务失败：%w", err)
+	}
+	if err := t.engine.Pool().Submit(func() { t.run(job, fn) }); err != nil {
+		t.finish(job, nil, fmt.Errorf("提交到协程池失败：%w", err))
+		return AsyncJob{}, fmt.Errorf("提交异步任务失败：%w", err)
+	}
+	return job, nil
+}
+
+// Accept 创建并提交任务，以 202 Accepted 响应任务 ID 与状态地址（同时写入 Location 响应头）
+func (t *AsyncJobTracker) Accept(ctx *gin.Context, kind string, fn AsyncJobFunc) {
+	job, err := t.Submit(ctx.Request.Context(), kind, fn)
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	statusURL := t.StatusURL(job.ID)
+	ctx.Header("Location", statusURL)
+	ctx.JSON(http.StatusAccepted, Response[gin.H]{
+		Msg:  "accepted",
+		Data: gin.H{"job_id": job.ID, "status": job.Status, "status_url": statusURL},
+	})
+}
+
+// StatusHandler 任务状态查询处理器，路由参数 id 为任务 ID
+// 任务不存在时通过 ctx.Error 传递包装 ErrResourceNotFound 的错误，默认渲染为 404
+func (t *AsyncJobTracker) StatusHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		job, err := t.engine.AsyncJobs().Get(ctx.Request.Context(), ctx.Param("id"))
+		if errors.Is(err, ErrAsyncJobNotFound) {
+			_ = ctx.Error(fmt.Errorf("异步任务不存在（%s）: %w: %w", ctx.Param("id"), err, ErrResourceNotFound))
+			ctx.Abort()
+			return
+		}
+		if err != nil {
+			_ = ctx.Error(fmt.Errorf("查询异步任务失败: %w", err))
+			ctx.Abort()
+			return
+		}
+		ctx.JSON(http.StatusOK, Response[AsyncJob]{Msg: "ok", Data: job})
+	}
+}
+
+// run 在协程池中执行任务并记录状态变化
+func (t *AsyncJobTracker) run(job AsyncJob, fn AsyncJobFunc) {
+	job.Status = AsyncJobRunning
+	job.UpdatedAt = t.engine.Clock().Now()
+	if err := t.engine.AsyncJobs().Save(context.Background(), job); err != nil {
+		t.engine.Logger().Error("更新异步任务状态失败", "job_id", job.ID, "status", job.Status, "error", err)
+	}
+
+	result, err := callAsyncJob(fn)
+	t.finish(job, result, err)
+}
+
+// finish 记录任务最终状态
+func (t *AsyncJobTracker) finish(job AsyncJob, result any, err error) {
+	if err == nil && result != nil {
+		raw, merr := json.Marshal(result)
+		if merr != nil {
+			err = fmt.Errorf("序列化任务结果失败：%w", merr)
+		} else {
+			job.Result = raw
+		}
+	}
+	job.Status = AsyncJobDone
+	if err != nil {
+		job.Status = AsyncJobFailed
+		job.Error = err.Error()
+		t.engine.Logger().Error("异步任务执行失败", "job_id", job.ID, "kind", job.Kind, "error", err)
+	}
+	job.UpdatedAt = t.engine.Clock().Now()
+	if serr := t.engine.AsyncJobs().Save(context.Background(), job); serr != nil {
+		t.engine.Logger().Error("更新异步任务状态失败", "job_id", job.ID, "status", job.Status, "error", serr)
+	}
+}
+
+// callAsyncJob 调用任务函数，将 panic 转换为错误
+func callAsyncJob(fn AsyncJobFunc) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("任务发生 panic：%v", r)
+		}
+	}()
+	return fn(context.Background())
+}
+
+// memoryAsyncJobStore 基于内存的异步任务存储，超出容量时淘汰最早创建的任务
+type memoryAsyncJobStore struct {
+	mu       sync.RWMutex
+	capacity int
+	jobs     map[string]AsyncJob
+	order    []string // 按创建顺序排列的任务 ID
+}
+
+// NewMemoryAsyncJobStore 创建内存异步任务存储
+// capacity <= 0 时使用默认容量 10000
+func NewMemoryAsyncJobStore(capacity int) AsyncJobStore {
+	if capacity <= 0 {
+		capacity = defaultAsyncJobCapacity
+	}
+	return &memoryAsyncJobStore{capacity: capacity, jobs: make(map[string]AsyncJob)}
+}
+
+func (s *memoryAsyncJobStore) Save(_ context.Context, job AsyncJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.ID]; !ok {
+		s.order = append(s.order, job.ID)
+	}
+	s.jobs[job.ID] = job
+	for len(s.order) > s.capacity {
+		delete(s.jobs, s.order[0])
+		s.order = s.order[1:]
+	}
+	return nil
+}
+
+func (s *memoryAsyncJobStore) Get(_ context.Context, id string) (AsyncJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return AsyncJob{}, ErrAsyncJobNotFound
+	}
+	return job, nil
+}
+
+// asyncJobRecord 异步任务的数据库模型
+type asyncJobRecord struct {
+	ID        string    `gorm:"primaryKey;size:64"`
+	Kind      string    `gorm:"size:255;index"`
+	Status    string    `gorm:"size:16;index"`
+	Result    []byte    `gorm:"type:blob"`
+	Error     string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+// gormAsyncJobStore 基于 GORM 的异步任务存储
+type gormAsyncJobStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormAsyncJobStore 创建数据库异步任务存储，并自动迁移任务表
+func NewGormAsyncJobStore(db *gorm.DB, table string) (AsyncJobStore, error) {
+	if db == nil {
+		return nil, errors.New("数据库未初始化")
+	}
+	if table == "" {
+		table = defaultAsyncJobTable
+	}
+	if err := db.Table(table).AutoMigrate(&asyncJobRecord{}); err != nil {
+		return nil, fmt.Errorf("迁移异步任务表失败：%w", err)
+	}
+	return &gormAsyncJobStore{db: db, table: table}, nil
+}
+
+func (s *gormAsyncJobStore) Save(ctx context.Context, job AsyncJob) error {
+	rec := asyncJobRecord{
+		ID:        job.ID,
+		Kind:      job.Kind,
+		Status:    string(job.Status),
+		Result:    job.Result,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+	return s.db.WithContext(ctx).Table(s.table).Save(&rec).Error
+}
+
+func (s *gormAsyncJobStore) Get(ctx context.Context, id string) (AsyncJob, error) {
+	var rec asyncJobRecord
+	err := s.db.WithContext(ctx).Table(s.table).Where("id = ?", id).First(&rec).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return AsyncJob{}, ErrAsyncJobNotFound
+	}
+	if err != nil {
+		return AsyncJob{}, err
+	}
+	return AsyncJob{
+		ID:        rec.ID,
+		Kind:      rec.Kind,
+		Status:    AsyncJobStatus(rec.Status),
+		Result:    rec.Result,
+		Error:     rec.Error,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt,
+	}, nil
+}
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
 )
 
 // 认证相关错误
@@ -15,8 +18,68 @@ var (
 	ErrInvalidToken      = errors.New("invalid token")
 	ErrTokenExpired      = errors.New("token expired")
 	ErrInvalidSigningKey = errors.New("invalid signing key")
+	ErrInvalidAudience   = errors.New("invalid audience")
+	// ErrNotAuthenticated 请求未经认证时从 DI 容器获取 UserTokenClaims 返回的错误，包装 ErrUnauthorized
+	ErrNotAuthenticated = fmt.Errorf("not authenticated: %w", ErrUnauthorized)
 )
 
+// errAuthorizationUnavailable 权限控制器未初始化时授权中间件返回的错误
+var errAuthorizationUnavailable = fmt.Errorf("授权子系统未初始化: %w", ErrInternalServer)
+
+// errJWTSecretMissing 未配置 auth.jwt_secret 时签发或校验令牌返回的错误
+var errJWTSecretMissing = fmt.Errorf("JWT 密钥未配置: %w", ErrInternalServer)
+
+// AuthConfig 认证配置
+type AuthConfig struct {
+	JWTSecret        string   `mapstructure:"jwt_secret"`         // HMAC 签名密钥
+	Issuer           string   `mapstructure:"issuer"`             // 令牌签发者（iss），配置后解析时强制校验
+	Audience         []string `mapstructure:"audience"`           // 令牌受众（aud），配置后要求令牌受众与之存在交集
+	ClockSkewSeconds int      `mapstructure:"clock_skew_seconds"` // 校验 exp/nbf/iat 时允许的时钟偏差（秒），默认 0
+
+	// AllowedAlgorithms 允许的签名算法白名单，默认与签名算法一致（HS256）
+	// "none" 始终被拒绝，即使出现在配置中
+	AllowedAlgorithms []string `mapstructure:"allowed_algorithms"`
+}
+
+// tokenSigningMethod 框架签发令牌使用的签名算法
+var tokenSigningMethod = jwt.SigningMethodHS256
+
+// hmacAlgorithms ParseToken 默认接受的算法（HMAC 系列）
+var hmacAlgorithms = []string{
+	jwt.SigningMethodHS256.Alg(),
+	jwt.SigningMethodHS384.Alg(),
+	jwt.SigningMethodHS512.Alg(),
+}
+
+// loadAuthConfig 从配置读取认证配置（auth.*）
+// 每次调用都重新读取，便于配置变更后即时生效
+func loadAuthConfig(cfg *viper.Viper) AuthConfig {
+	return AuthConfig{
+		JWTSecret:        cfg.GetString("auth.jwt_secret"),
+		Issuer:           strings.TrimSpace(cfg.GetString("auth.issuer")),
+		Audience:         getStringSlice(cfg, "auth.audience", nil),
+		ClockSkewSeconds: cfg.GetInt("auth.clock_skew_seconds"),
+
+		AllowedAlgorithms: getStringSlice(cfg, "auth.allowed_algorithms", []string{tokenSigningMethod.Alg()}),
+	}
+}
+
+// parserOptions 根据认证配置构建 JWT 解析选项
+func (c AuthConfig) parserOptions() []jwt.ParserOption {
+	var opts []jwt.ParserOption
+	if c.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(c.Issuer))
+	}
+	if len(c.Audience) > 0 {
+		// WithAudience 传入多个值时，令牌受众只需命中其一
+		opts = append(opts, jwt.WithAudience(c.Audience...))
+	}
+	if c.ClockSkewSeconds > 0 {
+		opts = append(opts, jwt.WithLeeway(time.Duration(c.ClockSkewSeconds)*time.Second))
+	}
+	return opts
+}
+
 // contextKeyUserClaims 上下文键约定：存放用户声明
 const contextKeyUserClaims = "abe.user_claims"
 
@@ -69,6 +132,16 @@ type UserTokenClaims interface {
 	Roles() []string
 }
 
+// TenantTokenClaims 多租户用户令牌声明接口（可选）
+// 在 UserTokenClaims 基础上提供租户标识，供 AuthorizationMiddlewareInDomain 作为 Casbin 域使用
+type TenantTokenClaims interface {
+	UserTokenClaims
+
+	// TenantID 返回当前访问令牌所属的租户标识
+	// 返回空字符串表示令牌不属于任何租户
+	TenantID() string
+}
+
 // AuthenticationMiddleware 全局身份认证中间件（泛型版本）
 // 这是一个通用的 JWT 认证中间件，支持任何实现了 UserTokenClaims 接口的自定义声明类型
 //
@@ -76,13 +149,14 @@ type UserTokenClaims interface {
 //   - T: 必须实现 UserTokenClaims 接口的具体类型（UserTokenClaims 已包含 jwt.Claims）
 //
 // 功能：
-//   - 从 HTTP 请求头中提取 Authorization: Bearer {token}
+//   - 按 opts 指定的顺序提取令牌（默认仅 Authorization: Bearer {token}）
 //   - 使用配置的 JWT 密钥验证和解析令牌
 //   - 将解析出的用户声明（UserTokenClaims 接口）存储到 Gin 上下文
 //   - 处理各种认证错误并通过统一错误处理机制响应
 //
 // 参数：
 //   - engine: *Engine 实例，用于访问配置和其他依赖
+//   - opts: 令牌提取来源（FromHeader/FromCookie/FromQuery），按顺序尝试，取第一个非空令牌
 //
 // 返回：
 //   - gin.HandlerFunc: Gin 中间件函数
@@ -106,6 +180,12 @@ type UserTokenClaims interface {
 //	// 2. 使用自定义 Claims 类型创建中间件
 //	engine.Router().Use(abe.AuthenticationMiddleware[*MyAppClaims](engine))
 //
+//	// 嵌入式页面无法设置请求头时，可依次尝试 Cookie 与请求头
+//	engine.Router().Use(abe.AuthenticationMiddleware[*MyAppClaims](engine,
+//	    abe.FromCookie("access_token"),
+//	    abe.FromHeader(),
+//	))
+//
 //	// 3. 在处理器中获取声明
 //	func myHandler(ctx *gin.Context) {
 //	    claims, ok := abe.GetUserTokenClaims(ctx)
@@ -117,52 +197,45 @@ type UserTokenClaims interface {
 //	}
 //
 // 错误处理：
-//   - 未提供认证信息 -> ErrUnauthorized
+//   - 未提供认证信息 -> ErrUnauthorized（错误消息中列出已尝试的来源）
 //   - 认证头格式错误 -> ErrUnauthorized
 //   - 令牌已过期 -> ErrTokenExpired
 //   - 令牌签名无效 -> ErrUnauthorized
 //   - 令牌格式错误 -> ErrUnauthorized
+//   - 令牌受众不匹配（auth.audience）-> 同时包装 ErrInvalidAudience 与 ErrUnauthorized
 //   - 其他解析错误 -> ErrInternalServer
 //
 // 注意：
 //   - T 必须是指针类型（如 *MyAppClaims），因为 JWT 解析需要指针来填充数据
 //   - 解析后的声明可通过 GetUserTokenClaims(ctx) 以接口形式获取
 //   - 所有错误都会通过 ctx.Error() 传递给 errorHandlerMiddleware 统一处理
-func AuthenticationMiddleware[T UserTokenClaims](engine *Engine) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		// 1. 提取 Authorization 头
-		authHeader := ctx.GetHeader("Authorization")
-		if authHeader == "" {
-			_ = ctx.Error(fmt.Errorf("未提供认证信息: %w", ErrUnauthorized))
-			ctx.Abort()
-			return
-		}
-
-		// 2. 验证 Bearer 格式
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-			_ = ctx.Error(fmt.Errorf("认证头格式错误，应为 'Bearer {token}': %w", ErrUnauthorized))
-			ctx.Abort()
-			return
-		}
-
-		tokenString := parts[1]
+func AuthenticationMiddleware[T UserTokenClaims](engine *Engine, opts ...AuthExtractOption) gin.HandlerFunc {
+	engine.authnRegistered.Store(true)
+	extractors := opts
+	if len(extractors) == 0 {
+		extractors = []AuthExtractOption{FromHeader()}
+	}
 
-		// 3. 获取 JWT 配置
-		secret := engine.Config().GetString("auth.jwt_secret")
-		if secret == "" {
-			_ = ctx.Error(fmt.Errorf("JWT 密钥未配置: %w", ErrInternalServer))
+	return func(ctx *gin.Context) {
+		// 1. 按顺序尝试各个令牌来源，取第一个非空令牌
+		tokenString, err := extractToken(ctx, extractors)
+		if err != nil {
+			_ = ctx.Error(err)
 			ctx.Abort()
 			return
 		}
 
-		// 4. 解析令牌 - 使用泛型类型 T
-		claims, err := ParseToken[T](tokenString, secret)
+		// 2. 解析令牌 - 使用泛型类型 T，并按配置校验签名、签发者与受众
+		claims, err := VerifyToken[T](engine, tokenString)
 		if err != nil {
-			// 5. 错误分类处理
+			// 3. 错误分类处理
 			switch {
+			case errors.Is(err, errJWTSecretMissing):
+				_ = ctx.Error(err)
 			case errors.Is(err, jwt.ErrTokenExpired):
 				_ = ctx.Error(fmt.Errorf("令牌已过期: %w", ErrTokenExpired))
+			case errors.Is(err, ErrInvalidAudience):
+				_ = ctx.Error(fmt.Errorf("令牌受众不匹配: %w: %w", ErrInvalidAudience, ErrUnauthorized))
 			case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrInvalidSigningKey):
 				_ = ctx.Error(fmt.Errorf("无效令牌: %w", ErrUnauthorized))
 			default:
@@ -172,15 +245,85 @@ func AuthenticationMiddleware[T UserTokenClaims](engine *Engine) gin.HandlerFunc
 			return
 		}
 
-		// 6. 将声明存储到上下文
+		// 4. 将声明存储到上下文
 		// 存储为 UserTokenClaims 接口类型，方便后续使用
 		ctx.Set(contextKeyUserClaims, UserTokenClaims(claims))
 
-		// 7. 继续处理请求
+		// 5. 继续处理请求
 		ctx.Next()
 	}
 }
 
+// AuthExtractOption 令牌提取来源
+// 由 FromHeader、FromCookie、FromQuery 创建，传入 AuthenticationMiddleware 后按顺序尝试
+type AuthExtractOption struct {
+	source  string                                 // 来源描述，用于错误消息
+	extract func(ctx *gin.Context) (string, error) // 提取函数，未找到令牌时返回空字符串
+}
+
+// FromHeader 从 Authorization 请求头提取 Bearer 令牌
+// 请求头存在但格式不是 'Bearer {token}' 时返回 ErrUnauthorized
+func FromHeader() AuthExtractOption {
+	return AuthExtractOption{
+		source: "header(Authorization)",
+		extract: func(ctx *gin.Context) (string, error) {
+			authHeader := ctx.GetHeader("Authorization")
+			if authHeader == "" {
+				return "", nil
+			}
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+				return "", fmt.Errorf("认证头格式错误，应为 'Bearer {token}': %w", ErrUnauthorized)
+			}
+			return parts[1], nil
+		},
+	}
+}
+
+// FromCookie 从指定名称的 Cookie 提取令牌
+func FromCookie(name string) AuthExtractOption {
+	return AuthExtractOption{
+		source: "cookie(" + name + ")",
+		extract: func(ctx *gin.Context) (string, error) {
+			v, err := ctx.Cookie(name)
+			if err != nil {
+				return "", nil
+			}
+			return v, nil
+		},
+	}
+}
+
+// FromQuery 从指定名称的查询参数提取令牌
+// 注意：查询参数可能被记录到访问日志或代理日志中，仅在无法使用请求头或 Cookie 时使用
+func FromQuery(name string) AuthExtractOption {
+	return AuthExtractOption{
+		source: "query(" + name + ")",
+		extract: func(ctx *gin.Context) (string, error) {
+			return ctx.Query(name), nil
+		},
+	}
+}
+
+// extractToken 按顺序尝试各个来源，返回第一个非空令牌
+func extractToken(ctx *gin.Context, extractors []AuthExtractOption) (string, error) {
+	sources := make([]string, 0, len(extractors))
+	for _, ex := range extractors {
+		if ex.extract == nil {
+			continue
+		}
+		sources = append(sources, ex.source)
+		token, err := ex.extract(ctx)
+		if err != nil {
+			return "", err
+		}
+		if token = strings.TrimSpace(token); token != "" {
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("未提供认证信息（已尝试: %s）: %w", strings.Join(sources, ", "), ErrUnauthorized)
+}
+
 // GetUserTokenClaims 从 Gin 上下文中获取用户令牌声明
 // 这是一个辅助函数，用于从上下文中提取实现了 UserTokenClaims 接口的声明
 //
@@ -257,7 +400,7 @@ func NewToken[T jwt.Claims](claims T, secret string) (string, error) {
 		return "", fmt.Errorf("密钥不能为空: %w", ErrInvalidSigningKey)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(tokenSigningMethod, claims)
 	signedToken, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return "", fmt.Errorf("签名令牌失败: %w", err)
@@ -266,6 +409,96 @@ func NewToken[T jwt.Claims](claims T, secret string) (string, error) {
 	return signedToken, nil
 }
 
+// GenerateToken 使用引擎配置生成 JWT 令牌的泛型函数
+// 使用 auth.jwt_secret 签名；若声明内嵌 jwt.RegisteredClaims 且 iss/aud 为空，
+// 则分别以 auth.issuer 与 auth.audience 填充，确保与 AuthenticationMiddleware 的校验一致；
+// iat 为空时以引擎时钟（Engine.Clock）的当前时间填充
+//
+// 类型参数：
+//   - T: 必须实现 jwt.Claims 接口的声明类型（需为指针类型才能回填 iss/aud/iat）
+//
+// 参数：
+//   - engine: *Engine 实例，用于读取认证配置
+//   - claims: JWT 声明数据
+//
+// 返回值：
+//   - string: 签名后的 JWT 字符串
+//   - error: 签名过程中的错误
+func GenerateToken[T jwt.Claims](engine *Engine, claims T) (string, error) {
+	authCfg := loadAuthConfig(engine.Config())
+	if rc := registeredClaimsOf(claims); rc != nil {
+		if rc.Issuer == "" {
+			rc.Issuer = authCfg.Issuer
+		}
+		if len(rc.Audience) == 0 && len(authCfg.Audience) > 0 {
+			rc.Audience = append(jwt.ClaimStrings(nil), authCfg.Audience...)
+		}
+		if rc.IssuedAt == nil {
+			rc.IssuedAt = jwt.NewNumericDate(engine.Clock().Now())
+		}
+	}
+	return NewToken(claims, authCfg.JWTSecret)
+}
+
+// GenerateToken 使用引擎配置为用户声明签发令牌，等同于 abe.GenerateToken(e, claims)
+// 未配置 auth.jwt_secret 时返回包装 ErrInternalServer 的错误
+//
+// 使用示例:
+//
+//	token, err := e.GenerateToken(&MyClaims{UID: user.ID, PrimaryRole: "admin"})
+func (e *Engine) GenerateToken(claims UserTokenClaims) (string, error) {
+	if loadAuthConfig(e.Config()).JWTSecret == "" {
+		return "", errJWTSecretMissing
+	}
+	return GenerateToken(e, claims)
+}
+
+// VerifyToken 使用引擎配置解析并校验令牌，校验规则与 AuthenticationMiddleware 一致：
+// auth.jwt_secret 签名、auth.allowed_algorithms 算法白名单、auth.issuer / auth.audience 以及引擎时钟下的有效期
+// 方法不能声明类型参数，因此以泛型函数形式提供，与 GenerateToken 对应
+//
+// 返回值：
+//   - T: 解析后的声明（需为指针类型，如 *MyClaims）
+//   - error: 未配置 auth.jwt_secret 时返回包装 ErrInternalServer 的错误，其余错误同 ParseToken
+//
+// 使用示例:
+//
+//	claims, err := abe.VerifyToken[*MyClaims](e, refreshToken)
+//	if errors.Is(err, jwt.ErrTokenExpired) {
+//	    // 刷新令牌已过期，需要重新登录
+//	}
+func VerifyToken[T jwt.Claims](engine *Engine, tokenString string) (T, error) {
+	authCfg := loadAuthConfig(engine.Config())
+	if authCfg.JWTSecret == "" {
+		var zero T
+		return zero, errJWTSecretMissing
+	}
+	opts := append(authCfg.parserOptions(), jwt.WithTimeFunc(engine.Clock().Now))
+	return parseToken[T](tokenString, authCfg.JWTSecret, authCfg.AllowedAlgorithms, opts...)
+}
+
+// registeredClaimsOf 通过反射获取声明中内嵌的 *jwt.RegisteredClaims
+// 声明本身即为 *jwt.RegisteredClaims 或内嵌 RegisteredClaims 字段时返回其指针，否则返回 nil
+func registeredClaimsOf(claims any) *jwt.RegisteredClaims {
+	if rc, ok := claims.(*jwt.RegisteredClaims); ok {
+		return rc
+	}
+	v := reflect.ValueOf(claims)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName("RegisteredClaims")
+	if !f.IsValid() || !f.CanAddr() {
+		return nil
+	}
+	rc, _ := f.Addr().Interface().(*jwt.RegisteredClaims)
+	return rc
+}
+
 // ParseToken 解析并验证 JWT 令牌的泛型函数
 // 使用 HS256 (HMAC SHA256) 算法验证令牌签名并解析声明
 //
@@ -275,6 +508,7 @@ func NewToken[T jwt.Claims](claims T, secret string) (string, error) {
 // 参数：
 //   - tokenString: 待解析的 JWT 字符串
 //   - secret: HMAC 验证密钥（必须与生成时使用的密钥一致）
+//   - opts: 额外的 JWT 解析选项（如 jwt.WithIssuer、jwt.WithAudience）
 //
 // 返回值：
 //   - T: 解析后的声明数据
@@ -284,6 +518,8 @@ func NewToken[T jwt.Claims](claims T, secret string) (string, error) {
 //   - 令牌过期：返回包装了 jwt.ErrTokenExpired 的错误
 //   - 签名无效：返回包装了 ErrInvalidSigningKey 的错误
 //   - 令牌格式错误：返回包装了 ErrInvalidToken 的错误
+//   - 签发者不匹配：返回包装了 ErrInvalidToken 的错误
+//   - 受众不匹配：返回同时包装了 ErrInvalidAudience 与 ErrInvalidToken 的错误
 //   - 其他错误：返回原始错误
 //
 // 示例：
@@ -300,7 +536,13 @@ func NewToken[T jwt.Claims](claims T, secret string) (string, error) {
 //	    }
 //	    // 处理其他错误
 //	}
-func ParseToken[T jwt.Claims](tokenString string, secret string) (T, error) {
+func ParseToken[T jwt.Claims](tokenString string, secret string, opts ...jwt.ParserOption) (T, error) {
+	return parseToken[T](tokenString, secret, hmacAlgorithms, opts...)
+}
+
+// parseToken ParseToken 的实现，allowedAlgs 为签名算法白名单
+// 密钥函数中显式校验 alg：拒绝 "none"、非 HMAC 算法以及白名单之外的算法，防止算法替换攻击
+func parseToken[T jwt.Claims](tokenString string, secret string, allowedAlgs []string, opts ...jwt.ParserOption) (T, error) {
 	var zero T
 
 	if secret == "" {
@@ -326,11 +568,18 @@ func ParseToken[T jwt.Claims](tokenString string, secret string) (T, error) {
 	// 解析令牌
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
 		// 验证签名方法
+		alg := token.Method.Alg()
+		if alg == "" || strings.EqualFold(alg, jwt.SigningMethodNone.Alg()) {
+			return nil, fmt.Errorf("signing method none is not allowed: %w", ErrInvalidSigningKey)
+		}
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v: %w", token.Header["alg"], ErrInvalidSigningKey)
 		}
+		if !slices.Contains(allowedAlgs, alg) {
+			return nil, fmt.Errorf("signing method %s is not allowed: %w", alg, ErrInvalidSigningKey)
+		}
 		return []byte(secret), nil
-	})
+	}, opts...)
 
 	if err != nil {
 		// 处理 JWT 相关错误
@@ -346,6 +595,15 @@ func ParseToken[T jwt.Claims](tokenString string, secret string) (T, error) {
 		if errors.Is(err, jwt.ErrTokenNotValidYet) {
 			return zero, fmt.Errorf("令牌尚未生效: %w", ErrInvalidToken)
 		}
+		if errors.Is(err, jwt.ErrTokenInvalidIssuer) {
+			return zero, fmt.Errorf("令牌签发者不匹配: %w", ErrInvalidToken)
+		}
+		if errors.Is(err, jwt.ErrTokenInvalidAudience) {
+			return zero, fmt.Errorf("令牌受众不匹配: %w: %w", ErrInvalidAudience, ErrInvalidToken)
+		}
+		if errors.Is(err, ErrInvalidSigningKey) {
+			return zero, fmt.Errorf("令牌签名算法不被允许: %w", ErrInvalidSigningKey)
+		}
 		// 其他错误
 		return zero, fmt.Errorf("解析令牌失败: %w", err)
 	}
@@ -363,6 +621,41 @@ func ParseToken[T jwt.Claims](tokenString string, secret string) (T, error) {
 	return zero, fmt.Errorf("令牌声明类型不匹配: %w", ErrInvalidToken)
 }
 
+// RequireAudience 路由级受众校验中间件
+// 要求当前令牌的 aud 至少包含 auds 中的一个，用于同一认证服务为多个客户端签发令牌、
+// 而各接口只接受特定客户端令牌的场景；须在 AuthenticationMiddleware 之后使用
+//
+// 参数：
+//   - auds: 当前路由接受的受众列表，为空时不做校验
+//
+// 错误处理：
+//   - 未认证（无法获取用户声明）-> ErrUnauthorized
+//   - 受众不匹配 -> 同时包装 ErrInvalidAudience 与 ErrForbidden
+func RequireAudience(auds ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if len(auds) == 0 {
+			ctx.Next()
+			return
+		}
+
+		claims, ok := GetUserTokenClaims(ctx)
+		if !ok {
+			_ = ctx.Error(fmt.Errorf("未认证的用户: %w", ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		tokenAuds, err := claims.GetAudience()
+		if err != nil || !slices.ContainsFunc(tokenAuds, func(a string) bool { return slices.Contains(auds, a) }) {
+			_ = ctx.Error(fmt.Errorf("令牌不适用于当前接口，要求受众 %v: %w: %w", auds, ErrInvalidAudience, ErrForbidden))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
 // AuthorizationMiddleware 全局权限鉴权中间件
 // 这是一个通用的 Casbin 权限检查中间件，支持任何实现了 UserTokenClaims 接口的自定义声明类型
 //
@@ -408,13 +701,16 @@ func ParseToken[T jwt.Claims](tokenString string, secret string) (T, error) {
 //
 // 错误处理：
 //   - 未认证（无法获取用户声明）-> ErrUnauthorized
+//   - 权限控制器未初始化 -> ErrInternalServer
 //   - 权限不足 -> ErrForbidden
 //
 // 注意：
 //   - 此中间件必须在 AuthenticationMiddleware 之后使用
 //   - 需要预先在 Casbin 中配置好权限策略
 //   - 角色名称直接使用 "role:" + roleName 格式，不进行类型转换
+//   - casbin.model=rbac_domain 时应使用 AuthorizationMiddlewareInDomain；模型参数不匹配导致鉴权执行出错时记录错误日志并按权限不足处理
 func AuthorizationMiddleware(engine *Engine, resource string, action string) gin.HandlerFunc {
+	engine.authzRegistered.Store(true)
 	return func(ctx *gin.Context) {
 		// 1. 从上下文获取用户声明
 		claims, ok := GetUserTokenClaims(ctx)
@@ -424,7 +720,14 @@ func AuthorizationMiddleware(engine *Engine, resource string, action string) gin
 			return
 		}
 
-		// 2. 检查权限
+		// 2. 权限控制器未初始化（禁用或初始化失败）时返回内部错误，避免空指针 panic
+		if engine.Enforcer() == nil {
+			_ = ctx.Error(errAuthorizationUnavailable)
+			ctx.Abort()
+			return
+		}
+
+		// 3. 检查权限
 		if !checkPermission(engine, claims, resource, action) {
 			_ = ctx.Error(fmt.Errorf("权限不足，无法访问此资源: %w", ErrForbidden))
 			ctx.Abort()
@@ -443,12 +746,10 @@ func checkPermission(engine *Engine, claims UserTokenClaims, resource, action st
 		return true
 	}
 
-	enforcer := engine.Enforcer()
-
 	// 1. 检查用户特殊权限
 	// 格式："user:{userID}"
 	userSub := "user:" + claims.UserID()
-	if allowed, _ := enforcer.Enforce(userSub, resource, action); allowed {
+	if enforce(engine, userSub, resource, action) {
 		return true
 	}
 
@@ -470,7 +771,106 @@ func checkPermission(engine *Engine, claims UserTokenClaims, resource, action st
 			continue
 		}
 		roleSub := "role:" + roleName
-		if allowed, _ := enforcer.Enforce(roleSub, resource, action); allowed {
+		if enforce(engine, roleSub, resource, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enforce 执行 Casbin 鉴权；执行出错（如请求参数个数与 casbin.model 不匹配）时记录错误日志并按拒绝处理
+func enforce(engine *Engine, rvals ...any) bool {
+	allowed, err := engine.Enforcer().Enforce(rvals...)
+	if err != nil {
+		engine.Logger().Error("Casbin 鉴权执行失败，按拒绝处理", "request", rvals, "model", engine.Config().GetString("casbin.model"), "error", err)
+		return false
+	}
+	return allowed
+}
+
+// AuthorizationMiddlewareInDomain 多租户权限鉴权中间件
+// 与 AuthorizationMiddleware 相同，但将令牌声明中的 TenantID 作为 Casbin 域参与鉴权，
+// 需配置 casbin.model=rbac_domain，且声明类型实现 TenantTokenClaims 接口
+//
+// 参数：
+//   - engine: *Engine 实例，用于访问 Casbin enforcer
+//   - resource: 资源标识符（如 "/api/users" 或自定义资源名）
+//   - action: 操作类型（如 "read", "write", "delete" 等）
+//
+// 返回：
+//   - gin.HandlerFunc: Gin 中间件函数
+//
+// 错误处理：
+//   - 未认证（无法获取用户声明）-> ErrUnauthorized
+//   - 声明未提供租户标识 -> ErrForbidden
+//   - 权限控制器未初始化 -> ErrInternalServer
+//   - 权限不足 -> ErrForbidden
+func AuthorizationMiddlewareInDomain(engine *Engine, resource string, action string) gin.HandlerFunc {
+	engine.authzRegistered.Store(true)
+	return func(ctx *gin.Context) {
+		claims, ok := GetUserTokenClaims(ctx)
+		if !ok {
+			_ = ctx.Error(fmt.Errorf("未认证的用户: %w", ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		tenantID := TenantIDFromClaims(claims)
+		if tenantID == "" {
+			_ = ctx.Error(fmt.Errorf("令牌缺少租户信息: %w", ErrForbidden))
+			ctx.Abort()
+			return
+		}
+
+		if engine.Enforcer() == nil {
+			_ = ctx.Error(errAuthorizationUnavailable)
+			ctx.Abort()
+			return
+		}
+
+		if !checkPermissionInDomain(engine, claims, tenantID, resource, action) {
+			_ = ctx.Error(fmt.Errorf("权限不足，无法访问此资源: %w", ErrForbidden))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// TenantIDFromClaims 从用户声明中提取租户标识；声明未实现 TenantTokenClaims 时返回空字符串
+func TenantIDFromClaims(claims UserTokenClaims) string {
+	if tc, ok := claims.(TenantTokenClaims); ok {
+		return tc.TenantID()
+	}
+	return ""
+}
+
+// checkPermissionInDomain 在指定域（租户）内检查用户权限，规则与 checkPermission 一致
+func checkPermissionInDomain(engine *Engine, claims UserTokenClaims, domain, resource, action string) bool {
+	if claims.UserID() == "1" {
+		return true
+	}
+
+	userSub := "user:" + claims.UserID()
+	if enforce(engine, userSub, domain, resource, action) {
+		return true
+	}
+
+	roles := claims.Roles()
+	if len(roles) == 0 {
+		if role := claims.Role(); role != "" {
+			roles = []string{role}
+		}
+	}
+
+	for _, roleName := range roles {
+		if roleName == "" {
+			continue
+		}
+		roleSub := "role:" + roleName
+		if enforce(engine, roleSub, domain, resource, action) {
 			return true
 		}
 	}
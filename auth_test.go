@@ -0,0 +1,247 @@
+package abe
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v3"
+	"github.com/casbin/casbin/v3/model"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+const testJWTSecret = "test-secret"
+
+// testClaims 测试用令牌声明
+type testClaims struct {
+	UID string `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+func (c *testClaims) UserID() string  { return c.UID }
+func (c *testClaims) Role() string    { return "" }
+func (c *testClaims) Roles() []string { return nil }
+
+// newAuthTestEngine 创建仅配置 auth.* 的引擎，未配置 allowed_algorithms 时只允许 HS256
+func newAuthTestEngine(settings map[string]any) *Engine {
+	cfg := viper.New()
+	cfg.Set("auth.jwt_secret", testJWTSecret)
+	for k, v := range settings {
+		cfg.Set(k, v)
+	}
+	return &Engine{config: cfg}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("签名令牌失败：%v", err)
+	}
+	return token
+}
+
+// parseTestToken 按引擎的 auth.* 配置（含算法白名单）解析令牌，与认证中间件一致
+func parseTestToken(e *Engine, token string) (*testClaims, error) {
+	cfg := loadAuthConfig(e.Config())
+	return parseToken[*testClaims](token, cfg.JWTSecret, cfg.AllowedAlgorithms, cfg.parserOptions()...)
+}
+
+func TestVerifyTokenUsesEngineClockAndConfig(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := newAuthTestEngine(map[string]any{"auth.clock_skew_seconds": 5})
+	e.SetClock(NewManualClock(now))
+
+	// nbf 比引擎时钟晚 3 秒，在允许的时钟偏差内；exp 按引擎时钟未到期（按真实时间早已过期）
+	claims := &testClaims{UID: "u1", RegisteredClaims: jwt.RegisteredClaims{
+		NotBefore: jwt.NewNumericDate(now.Add(3 * time.Second)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}}
+	got, err := VerifyToken[*testClaims](e, signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims))
+	if err != nil || got.UID != "u1" {
+		t.Fatalf("VerifyToken = %+v, %v", got, err)
+	}
+
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+	if _, err := VerifyToken[*testClaims](e, signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims)); err == nil {
+		t.Fatal("按引擎时钟已过期的令牌应被拒绝")
+	}
+
+	none := signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, &testClaims{UID: "u1"})
+	if _, err := VerifyToken[*testClaims](e, none); !errors.Is(err, ErrInvalidSigningKey) {
+		t.Fatalf("VerifyToken 错误 = %v，期望包装 ErrInvalidSigningKey", err)
+	}
+}
+
+func TestParseTokenRejectsDisallowedAlgorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成 RSA 密钥失败：%v", err)
+	}
+	claims := &testClaims{UID: "u1"}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "alg=none", token: signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims)},
+		{name: "RS256", token: signTestToken(t, jwt.SigningMethodRS256, rsaKey, claims)},
+		{name: "HS512 未在白名单中", token: signTestToken(t, jwt.SigningMethodHS512, []byte(testJWTSecret), claims)},
+	}
+
+	e := newAuthTestEngine(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseTestToken(e, tt.token); !errors.Is(err, ErrInvalidSigningKey) {
+				t.Fatalf("解析错误 = %v，期望包装 ErrInvalidSigningKey", err)
+			}
+		})
+	}
+
+	t.Run("HS256 通过", func(t *testing.T) {
+		got, err := parseTestToken(e, signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims))
+		if err != nil || got.UID != "u1" {
+			t.Fatalf("解析结果 = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("配置允许后 HS512 通过", func(t *testing.T) {
+		e := newAuthTestEngine(map[string]any{"auth.allowed_algorithms": []string{"HS256", "HS512"}})
+		if _, err := parseTestToken(e, tests[2].token); err != nil {
+			t.Fatalf("解析错误 = %v", err)
+		}
+	})
+}
+
+func TestAuthenticationMiddlewareRejectsNoneAlgorithm(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	e := newAuthTestEngine(nil)
+	var got error
+	reached := false
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Next()
+		if last := ctx.Errors.Last(); last != nil {
+			got = last.Err
+		}
+	}, AuthenticationMiddleware[*testClaims](e))
+	r.GET("/me", func(ctx *gin.Context) { reached = true })
+
+	token := signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, &testClaims{UID: "u1"})
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if reached || !errors.Is(got, ErrUnauthorized) {
+		t.Fatalf("alg=none 令牌应被拒绝：handler 执行 = %v，错误 = %v", reached, got)
+	}
+}
+
+func TestAuthenticationMiddlewareRendersUnauthorized(t *testing.T) {
+	e := newAuthTestEngine(nil)
+	r := newErrorTestRouter(AuthenticationMiddleware[*testClaims](e))
+	r.GET("/me", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	token := signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, &testClaims{UID: "u1"})
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("alg=none 令牌状态码 = %d，期望 401", w.Code)
+	}
+}
+
+func TestParseTokenClockSkew(t *testing.T) {
+	// nbf 比当前时间晚 10 秒，模拟签发方时钟略快
+	claims := &testClaims{UID: "u1", RegisteredClaims: jwt.RegisteredClaims{NotBefore: jwt.NewNumericDate(time.Now().Add(10 * time.Second))}}
+	token := signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims)
+
+	tests := []struct {
+		name    string
+		skew    int
+		wantErr bool
+	}{
+		{name: "未配置时钟偏差", skew: 0, wantErr: true},
+		{name: "偏差小于 nbf 提前量", skew: 5, wantErr: true},
+		{name: "偏差覆盖 nbf 提前量", skew: 30, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newAuthTestEngine(map[string]any{"auth.clock_skew_seconds": tt.skew})
+			_, err := parseTestToken(e, token)
+			if tt.wantErr && !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("ParseToken 错误 = %v，期望包装 ErrInvalidToken", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ParseToken 错误 = %v，期望通过", err)
+			}
+		})
+	}
+}
+
+func TestAuthenticationMiddlewareInvalidAudience(t *testing.T) {
+	e := newAuthTestEngine(map[string]any{"auth.audience": []string{"api"}})
+	var got error
+	capture := func(ctx *gin.Context) {
+		ctx.Next()
+		if last := ctx.Errors.Last(); last != nil {
+			got = last.Err
+		}
+	}
+	r := newErrorTestRouter(capture, AuthenticationMiddleware[*testClaims](e))
+	r.GET("/me", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	claims := &testClaims{UID: "u1", RegisteredClaims: jwt.RegisteredClaims{Audience: jwt.ClaimStrings{"admin"}}}
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("受众不匹配状态码 = %d，期望 401", w.Code)
+	}
+	if !errors.Is(got, ErrInvalidAudience) {
+		t.Fatalf("错误链应包含 ErrInvalidAudience，实际 %v", got)
+	}
+	if !strings.Contains(w.Body.String(), "受众不匹配") {
+		t.Fatalf("响应应说明受众不匹配，实际 %s", w.Body.String())
+	}
+}
+
+func TestAuthorizationMiddlewareLogsEnforceError(t *testing.T) {
+	m, err := model.NewModelFromString(rbacDomainModel)
+	if err != nil {
+		t.Fatalf("解析模型失败：%v", err)
+	}
+	enforcer, err := casbin.NewEnforcer(m)
+	if err != nil {
+		t.Fatalf("创建 Casbin 执行器失败：%v", err)
+	}
+	var logs bytes.Buffer
+	e := newAuthTestEngine(map[string]any{"casbin.model": "rbac_domain"})
+	e.enforcer = enforcer
+	e.logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	// rbac_domain 模型需要 4 个请求参数，普通授权中间件只传 3 个
+	setClaims := func(ctx *gin.Context) { ctx.Set(contextKeyUserClaims, UserTokenClaims(&testClaims{UID: "2"})) }
+	r := newErrorTestRouter(setClaims, AuthorizationMiddleware(e, "/orders", "read"))
+	r.GET("/orders", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("状态码 = %d，期望 403", w.Code)
+	}
+	if !strings.Contains(logs.String(), "Casbin 鉴权执行失败") {
+		t.Fatalf("鉴权执行出错时应记录错误日志，实际日志：%s", logs.String())
+	}
+}
@@ -0,0 +1,90 @@
+package abe
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BatchItemError 批量操作中单项的错误信息
+type BatchItemError struct {
+	Code    ErrorCode        `json:"code"`             // 与单条接口一致的错误码（HTTP 状态码）
+	Message string           `json:"message"`          // 面向用户的错误描述
+	Errors  []FieldViolation `json:"errors,omitempty"` // 字段级错误（FieldErrors）
+}
+
+// BatchItemResult 批量操作中单项的处理结果
+type BatchItemResult[T any] struct {
+	Index   int             `json:"index"`           // 在请求列表中的下标
+	Success bool            `json:"success"`         // 是否处理成功
+	Data    T               `json:"data,omitempty"`  // 成功时的结果
+	Error   *BatchItemError `json:"error,omitempty"` // 失败时的错误
+}
+
+// BatchResult 批量操作结果，逐项记录成功与失败
+type BatchResult[T any] struct {
+	Total     int                  `json:"total"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Items     []BatchItemResult[T] `json:"items"`
+}
+
+// RunBatch 逐项执行批量操作并收集结果，单项失败不会中断其余项
+// 单项错误按与单条接口相同的规则转换：FieldErrors 保留字段级错误，哨兵错误（ErrBadRequest、ErrForbidden 等）
+// 映射为对应状态码，其余错误视为内部错误且不向客户端暴露细节
+//
+// 使用示例:
+//
+//	result := abe.RunBatch(req.Users, func(i int, u CreateUserRequest) (*User, error) {
+//	    if exists(u.Email) {
+//	        return nil, abe.FieldError("email", "该邮箱已注册")
+//	    }
+//	    return create(u)
+//	})
+//	abe.WriteBatchResult(ctx, result)
+func RunBatch[I, O any](items []I, fn func(index int, item I) (O, error)) BatchResult[O] {
+	result := BatchResult[O]{
+		Total: len(items),
+		Items: make([]BatchItemResult[O], 0, len(items)),
+	}
+	for i, item := range items {
+		out, err := fn(i, item)
+		if err != nil {
+			result.Failed++
+			result.Items = append(result.Items, BatchItemResult[O]{Index: i, Error: newBatchItemError(err)})
+			continue
+		}
+		result.Succeeded++
+		result.Items = append(result.Items, BatchItemResult[O]{Index: i, Success: true, Data: out})
+	}
+	return result
+}
+
+// newBatchItemError 将单项错误转换为 BatchItemError
+func newBatchItemError(err error) *BatchItemError {
+	var fe FieldErrors
+	if errors.As(err, &fe) {
+		return &BatchItemError{Code: ErrorCode(http.StatusBadRequest), Message: "参数校验失败", Errors: fe}
+	}
+	status := sentinelStatus(err)
+	if status == http.StatusInternalServerError {
+		return &BatchItemError{Code: ErrorCode(status), Message: "内部错误"}
+	}
+	return &BatchItemError{Code: ErrorCode(status), Message: err.Error()}
+}
+
+// WriteBatchResult 输出批量操作结果
+// 全部成功时返回 200；存在失败项时返回 207 Multi-Status，响应体均为 Response[BatchResult[T]]
+// 客户端无法处理 207 时，可直接以 ctx.JSON(http.StatusOK, ...) 输出 BatchResult
+func WriteBatchResult[T any](ctx *gin.Context, result BatchResult[T]) {
+	if result.Failed == 0 {
+		ctx.JSON(http.StatusOK, Response[BatchResult[T]]{Msg: "ok", Data: result})
+		return
+	}
+	ctx.JSON(http.StatusMultiStatus, Response[BatchResult[T]]{
+		Code: ErrorCode(http.StatusMultiStatus),
+		Msg:  "部分操作失败",
+		Data: result,
+	})
+}
@@ -0,0 +1,143 @@
+package abe
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// multipartMemory 解析 multipart 表单时驻留内存的上限，超出部分写入临时文件（与 gin 默认值一致）
+const multipartMemory = 32 << 20
+
+// BodyParser 请求体解析器，只负责将请求体解码到 obj，校验由 BindBody 统一执行
+type BodyParser func(ctx *gin.Context, obj any) error
+
+var (
+	bodyParsersMu sync.RWMutex
+	bodyParsers   = map[string]BodyParser{
+		gin.MIMEJSON:              parseJSONBody,
+		gin.MIMEXML:               parseXMLBody,
+		gin.MIMEXML2:              parseXMLBody,
+		gin.MIMEPOSTForm:          parseFormBody,
+		gin.MIMEMultipartPOSTForm: parseFormBody,
+	}
+)
+
+// RegisterBodyParser 注册（或覆盖）指定 Content-Type 的请求体解析器，应在启动阶段调用
+// contentType 不含参数部分且大小写不敏感，如 "text/csv"
+//
+// 使用示例:
+//
+//	abe.RegisterBodyParser("text/csv", func(ctx *gin.Context, obj any) error {
+//	    rows, err := csv.NewReader(ctx.Request.Body).ReadAll()
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return mapCSVRows(rows, obj)
+//	})
+func RegisterBodyParser(contentType string, parser BodyParser) {
+	bodyParsersMu.Lock()
+	defer bodyParsersMu.Unlock()
+	bodyParsers[strings.ToLower(strings.TrimSpace(contentType))] = parser
+}
+
+// lookupBodyParser 按 Content-Type 查找解析器；未声明或未注册的类型按 JSON 解析
+func lookupBodyParser(contentType string) BodyParser {
+	bodyParsersMu.RLock()
+	defer bodyParsersMu.RUnlock()
+	if p, ok := bodyParsers[strings.ToLower(contentType)]; ok {
+		return p
+	}
+	return bodyParsers[gin.MIMEJSON]
+}
+
+// BindBody 按请求 Content-Type 解析请求体并执行校验
+// 内置 JSON、XML、表单（urlencoded / multipart 的普通字段）解析，可通过 RegisterBodyParser 扩展；
+// Content-Type 缺失或未注册时按 JSON 解析
+//
+// 错误处理：
+//   - 请求体格式错误 -> ErrBadRequest
+//   - 校验失败 -> FieldErrors（配合 FieldErrorsHandler 渲染字段级错误）
+//
+// 使用示例:
+//
+//	req, err := abe.BindBody[CreateUserRequest](ctx)
+//	if err != nil {
+//	    return nil, err
+//	}
+func BindBody[T any](ctx *gin.Context) (T, error) {
+	var obj T
+	if err := parseBody(ctx, &obj); err != nil {
+		return obj, err
+	}
+	return obj, validateRequest(ctx, &obj)
+}
+
+// parseBody 按 Content-Type 解析请求体（不校验），解析错误包装为 ErrBadRequest
+func parseBody(ctx *gin.Context, obj any) error {
+	if err := lookupBodyParser(ctx.ContentType())(ctx, obj); err != nil {
+		var fe FieldErrors
+		if errors.As(err, &fe) || errors.Is(err, ErrBadRequest) {
+			return err
+		}
+		return fmt.Errorf("请求体解析失败: %w: %w", err, ErrBadRequest)
+	}
+	return nil
+}
+
+// validateRequest 使用全局校验器校验请求对象，校验错误转换为 FieldErrors
+func validateRequest(ctx *gin.Context, obj any) error {
+	if binding.Validator == nil {
+		return nil
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return BindingFieldErrors(ctx, err)
+	}
+	return nil
+}
+
+func parseJSONBody(ctx *gin.Context, obj any) error {
+	if ctx.Request.Body == nil {
+		return errors.New("请求体为空")
+	}
+	dec := json.NewDecoder(ctx.Request.Body)
+	if binding.EnableDecoderUseNumber {
+		dec.UseNumber()
+	}
+	if binding.EnableDecoderDisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(obj); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("请求体为空")
+		}
+		return err
+	}
+	return nil
+}
+
+func parseXMLBody(ctx *gin.Context, obj any) error {
+	if ctx.Request.Body == nil {
+		return errors.New("请求体为空")
+	}
+	return xml.NewDecoder(ctx.Request.Body).Decode(obj)
+}
+
+func parseFormBody(ctx *gin.Context, obj any) error {
+	req := ctx.Request
+	if strings.EqualFold(ctx.ContentType(), gin.MIMEMultipartPOSTForm) {
+		if err := req.ParseMultipartForm(multipartMemory); err != nil {
+			return err
+		}
+	} else if err := req.ParseForm(); err != nil {
+		return err
+	}
+	return binding.MapFormWithTag(obj, req.PostForm, "form")
+}
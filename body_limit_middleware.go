@@ -0,0 +1,88 @@
+package abe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyTooLargeError 请求体超过大小限制
+// 同时包装 ErrBadRequest 与 *http.MaxBytesError，错误处理器可按任一方式识别
+type BodyTooLargeError struct {
+	Limit int64 // 允许的最大字节数
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("请求体过大（上限 %d 字节）", e.Limit)
+}
+
+func (e *BodyTooLargeError) Unwrap() []error {
+	return []error{ErrBadRequest, &http.MaxBytesError{Limit: e.Limit}}
+}
+
+// BodyLimitMiddleware 请求体大小限制中间件
+//
+// 参数:
+//   - maxBytes: 允许的最大请求体字节数，必须大于 0
+//
+// 返回:
+//   - gin.HandlerFunc: Gin 中间件函数
+//
+// 行为:
+//   - Content-Length 已声明且超过上限时，直接通过 ctx.Error 传递 *BodyTooLargeError 并中止链条
+//   - 否则使用 http.MaxBytesReader 包装 ctx.Request.Body 做流式计数（不缓冲请求体），读取超限时返回 *BodyTooLargeError，
+//     绑定（如 ctx.ShouldBindJSON）或 StreamBody 得到的错误经 ctx.Error 传递后由 SentinelErrorHandler 渲染为 400
+//
+// 配置 server.max_body_bytes 大于 0 时，引擎会为所有控制器路由自动挂载本中间件；
+// 同时配置 server.max_stream_body_bytes 时，流式上传（见 IsStreamingRequest）改用该上限。
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return bodyLimitMiddleware(maxBytes, maxBytes)
+}
+
+// bodyLimitMiddleware 按请求类型选择上限：流式上传使用 maxStreamBytes，其余使用 maxBytes
+func bodyLimitMiddleware(maxBytes, maxStreamBytes int64) gin.HandlerFunc {
+	if maxBytes <= 0 {
+		panic("BodyLimitMiddleware: maxBytes 必须大于 0")
+	}
+	if maxStreamBytes < maxBytes {
+		maxStreamBytes = maxBytes
+	}
+
+	return func(ctx *gin.Context) {
+		limit := maxBytes
+		if maxStreamBytes > maxBytes && IsStreamingRequest(ctx.Request, maxBytes) {
+			limit = maxStreamBytes
+		}
+
+		if ctx.Request.ContentLength > limit {
+			_ = ctx.Error(&BodyTooLargeError{Limit: limit})
+			ctx.Abort()
+			return
+		}
+		if ctx.Request.Body != nil && ctx.Request.Body != http.NoBody {
+			ctx.Request.Body = &limitedBody{
+				ReadCloser: http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit),
+				limit:      limit,
+			}
+		}
+		ctx.Next()
+	}
+}
+
+// limitedBody 将 *http.MaxBytesError 转换为 *BodyTooLargeError
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		return n, &BodyTooLargeError{Limit: b.limit}
+	}
+	return n, err
+}
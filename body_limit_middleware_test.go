@@ -0,0 +1,90 @@
+package abe
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testBodyLimit = 16
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		chunked bool // 不声明 Content-Length，走读取时的流式计数
+		wantErr bool
+	}{
+		{name: "恰好等于上限", size: testBodyLimit},
+		{name: "低于上限", size: testBodyLimit - 1},
+		{name: "超出上限一个字节", size: testBodyLimit + 1, wantErr: true},
+		{name: "流式读取恰好等于上限", size: testBodyLimit, chunked: true},
+		{name: "流式读取超出上限一个字节", size: testBodyLimit + 1, chunked: true, wantErr: true},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got error
+			r := gin.New()
+			r.Use(func(ctx *gin.Context) {
+				ctx.Next()
+				if last := ctx.Errors.Last(); last != nil {
+					got = last.Err
+				}
+			}, BodyLimitMiddleware(testBodyLimit))
+			r.POST("/upload", func(ctx *gin.Context) {
+				if _, err := io.ReadAll(ctx.Request.Body); err != nil {
+					_ = ctx.Error(err)
+					ctx.Abort()
+					return
+				}
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("x", tt.size)))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.wantErr {
+				if got != nil {
+					t.Fatalf("未超限时不应出错：%v", got)
+				}
+				return
+			}
+			var tooLarge *BodyTooLargeError
+			if !errors.As(got, &tooLarge) || !errors.Is(got, ErrBadRequest) {
+				t.Fatalf("错误 = %v，期望 *BodyTooLargeError 且包装 ErrBadRequest", got)
+			}
+			if !strings.Contains(got.Error(), "请求体过大") {
+				t.Fatalf("错误消息 = %q，期望包含 \"请求体过大\"", got.Error())
+			}
+		})
+	}
+}
+
+func TestBodyLimitMiddlewareRendersBadRequest(t *testing.T) {
+	r := newErrorTestRouter(BodyLimitMiddleware(testBodyLimit))
+	r.POST("/upload", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("x", testBodyLimit+1))))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("状态码 = %d，期望 400（响应：%s）", w.Code, w.Body.String())
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析错误响应失败：%v", err)
+	}
+	if !strings.Contains(resp.Msg, "请求体过大") {
+		t.Fatalf("错误消息 = %q，期望包含 \"请求体过大\"", resp.Msg)
+	}
+}
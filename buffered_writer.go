@@ -0,0 +1,123 @@
+package abe
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedWriter 缓冲处理器响应的 gin.ResponseWriter，供 TimeoutMiddleware、TransactionMiddleware 在结果确定后写出或丢弃
+// 丢弃缓冲区后忽略后续写入，保证同一请求只写出一次响应
+type bufferedWriter struct {
+	gin.ResponseWriter
+
+	mu        sync.Mutex // 处理器可能在自建 goroutine 中写入
+	header    http.Header
+	body      bytes.Buffer
+	status    int
+	written   bool
+	discarded bool
+}
+
+func newBufferedWriter(w gin.ResponseWriter) *bufferedWriter {
+	return &bufferedWriter{
+		ResponseWriter: w,
+		header:         w.Header().Clone(),
+		status:         http.StatusOK,
+	}
+}
+
+func (bw *bufferedWriter) Header() http.Header {
+	return bw.header
+}
+
+func (bw *bufferedWriter) WriteHeader(code int) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if bw.discarded || bw.written || code <= 0 {
+		return
+	}
+	bw.status = code
+}
+
+func (bw *bufferedWriter) WriteHeaderNow() {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	bw.written = true
+}
+
+func (bw *bufferedWriter) Write(data []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if bw.discarded {
+		return 0, http.ErrHandlerTimeout
+	}
+	bw.written = true
+	return bw.body.Write(data)
+}
+
+func (bw *bufferedWriter) WriteString(s string) (int, error) {
+	return bw.Write([]byte(s))
+}
+
+func (bw *bufferedWriter) Status() int {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.status
+}
+
+func (bw *bufferedWriter) Size() int {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if !bw.written {
+		return -1
+	}
+	return bw.body.Len()
+}
+
+func (bw *bufferedWriter) Written() bool {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.written
+}
+
+// Flush 缓冲期间不支持流式写出，忽略调用
+func (bw *bufferedWriter) Flush() {}
+
+func (bw *bufferedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("缓冲响应期间不支持连接劫持")
+}
+
+// flush 将缓冲的响应头、状态码与响应体写出到底层 ResponseWriter
+func (bw *bufferedWriter) flush() {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if bw.discarded {
+		return
+	}
+	bw.discarded = true
+
+	dst := bw.ResponseWriter.Header()
+	for k, v := range bw.header {
+		dst[k] = v
+	}
+	bw.ResponseWriter.WriteHeader(bw.status)
+	if !bw.written {
+		return
+	}
+	bw.ResponseWriter.WriteHeaderNow()
+	_, _ = bw.ResponseWriter.Write(bw.body.Bytes())
+}
+
+// discard 丢弃缓冲区，之后的写入均被忽略
+func (bw *bufferedWriter) discard() {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	bw.discarded = true
+	bw.body.Reset()
+}
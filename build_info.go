@@ -0,0 +1,73 @@
+package abe
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 构建信息，可在编译时通过 -ldflags 注入，例如:
+//
+//	go build -ldflags "-X github.com/otzgo/abe.Version=1.2.0 \
+//	    -X github.com/otzgo/abe.Commit=$(git rev-parse --short HEAD) \
+//	    -X github.com/otzgo/abe.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// 未注入 Commit / BuildTime 时回退为 Go 工具链记录的 VCS 信息（vcs.revision / vcs.time）
+var (
+	Commit    string // 构建对应的代码提交
+	BuildTime string // 构建时间（建议 RFC 3339）
+)
+
+// BuildInfo 运行中程序的构建信息
+type BuildInfo struct {
+	Version   string `json:"version"`              // 引擎版本，插件 MinEngineVersion 校验同样使用此值
+	Commit    string `json:"commit,omitempty"`     // 代码提交
+	BuildTime string `json:"build_time,omitempty"` // 构建时间
+	GoVersion string `json:"go_version"`           // 编译所用 Go 版本
+	Modified  bool   `json:"modified,omitempty"`   // 构建时工作区是否有未提交修改（仅 VCS 回退时可知）
+}
+
+var (
+	buildInfoOnce sync.Once
+	buildInfo     BuildInfo
+)
+
+// BuildInfo 返回构建信息
+func (e *Engine) BuildInfo() BuildInfo {
+	buildInfoOnce.Do(func() {
+		buildInfo = BuildInfo{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if buildInfo.Commit == "" {
+					buildInfo.Commit = s.Value
+				}
+			case "vcs.time":
+				if buildInfo.BuildTime == "" {
+					buildInfo.BuildTime = s.Value
+				}
+			case "vcs.modified":
+				buildInfo.Modified = s.Value == "true"
+			}
+		}
+	})
+	return buildInfo
+}
+
+// VersionHandler 构建信息查询处理器，以 JSON 返回 BuildInfo
+//
+// 使用示例:
+//
+//	engine.Router().GET("/version", abe.VersionHandler(engine))
+func VersionHandler(e *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, Response[BuildInfo]{Msg: "ok", Data: e.BuildInfo()})
+	}
+}
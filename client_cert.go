@@ -0,0 +1,195 @@
+package abe
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+// contextKeyClientCert 上下文键约定：存放已校验的客户端证书
+const contextKeyClientCert = "abe.client_cert"
+
+// 客户端证书校验模式（server.tls.client_auth）
+const (
+	clientAuthNone    = "none"    // 不请求客户端证书（默认）
+	clientAuthRequest = "request" // 请求证书，提供时必须通过校验
+	clientAuthRequire = "require" // 必须提供并通过校验的证书
+)
+
+// tlsEnabled 是否启用 HTTPS：显式配置 server.tls.enabled 时以其为准，否则以是否配置 server.tls.cert_file 判断
+func tlsEnabled(cfg *viper.Viper) bool {
+	if cfg.IsSet("server.tls.enabled") {
+		return cfg.GetBool("server.tls.enabled")
+	}
+	return cfg.GetString("server.tls.cert_file") != ""
+}
+
+// newServerTLSConfig 根据 server.tls.* 配置构建 HTTP 服务器的 TLS 配置
+// 未启用 TLS 时返回 nil（使用明文 HTTP）；配置了 client_ca、client_auth 或 redirect_http_port 但未启用 TLS 时返回错误
+// 返回的配置同时声明 h2 与 http/1.1，HTTPS 连接可协商 HTTP/2
+func newServerTLSConfig(cfg *viper.Viper) (*tls.Config, error) {
+	certFile := cfg.GetString("server.tls.cert_file")
+	keyFile := cfg.GetString("server.tls.key_file")
+	caFile := cfg.GetString("server.tls.client_ca")
+	mode := strings.ToLower(strings.TrimSpace(cfg.GetString("server.tls.client_auth")))
+
+	if !tlsEnabled(cfg) {
+		if caFile != "" || (mode != "" && mode != clientAuthNone) {
+			return nil, fmt.Errorf("启用客户端证书认证需先启用 TLS（server.tls.enabled 与 server.tls.cert_file/key_file）")
+		}
+		if cfg.GetInt("server.tls.redirect_http_port") > 0 {
+			return nil, fmt.Errorf("配置 server.tls.redirect_http_port 需先启用 TLS")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("启用 TLS 需同时配置 server.tls.cert_file 与 server.tls.key_file")
+	}
+	if port := cfg.GetInt("server.tls.redirect_http_port"); port < 0 || port > 65535 {
+		return nil, fmt.Errorf("无效的 server.tls.redirect_http_port：%d", port)
+	}
+
+	tc := &tls.Config{MinVersion: tls.VersionTLS12, NextProtos: []string{"h2", "http/1.1"}}
+	if mode == "" {
+		// 配置了客户端 CA 但未显式指定模式时默认强制校验
+		mode = clientAuthNone
+		if caFile != "" {
+			mode = clientAuthRequire
+		}
+	}
+	switch mode {
+	case clientAuthNone:
+		return tc, nil
+	case clientAuthRequest:
+		tc.ClientAuth = tls.VerifyClientCertIfGiven
+	case clientAuthRequire:
+		tc.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("无效的 server.tls.client_auth：%q，可选值：none、request、require", mode)
+	}
+	if caFile == "" {
+		return nil, fmt.Errorf("server.tls.client_auth=%s 需配置 server.tls.client_ca", mode)
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("读取客户端 CA 证书失败：%w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("客户端 CA 证书 %s 中没有有效的 PEM 证书", caFile)
+	}
+	tc.ClientCAs = pool
+	return tc, nil
+}
+
+// ClientCertClaims 由客户端证书映射得到的用户声明，实现 UserTokenClaims，可直接用于 AuthorizationMiddleware
+// - UserID 为 "cert:" + 证书 CN，与业务用户 ID 隔离（Casbin 主体形如 "user:cert:order-service"）
+// - Roles 为证书主题中的 OU 列表
+type ClientCertClaims struct {
+	jwt.RegisteredClaims
+	CommonName string   `json:"cn"`
+	Units      []string `json:"ou,omitempty"`
+	DNSNames   []string `json:"dns,omitempty"`
+	URIs       []string `json:"uris,omitempty"`
+}
+
+func (c *ClientCertClaims) UserID() string {
+	return "cert:" + c.CommonName
+}
+
+func (c *ClientCertClaims) Role() string {
+	if len(c.Units) > 0 {
+		return c.Units[0]
+	}
+	return ""
+}
+
+func (c *ClientCertClaims) Roles() []string {
+	return c.Units
+}
+
+// ClaimsFromClientCert 默认的证书身份映射：CN 作为主体，OU 作为角色，SAN 原样保留
+func ClaimsFromClientCert(cert *x509.Certificate) (UserTokenClaims, error) {
+	if cert.Subject.CommonName == "" {
+		return nil, fmt.Errorf("客户端证书缺少 CN: %w", ErrUnauthorized)
+	}
+	uris := make([]string, 0, len(cert.URIs))
+	for _, u := range cert.URIs {
+		uris = append(uris, u.String())
+	}
+	return &ClientCertClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   cert.Subject.CommonName,
+			Issuer:    cert.Issuer.CommonName,
+			NotBefore: jwt.NewNumericDate(cert.NotBefore),
+			ExpiresAt: jwt.NewNumericDate(cert.NotAfter),
+		},
+		CommonName: cert.Subject.CommonName,
+		Units:      cert.Subject.OrganizationalUnit,
+		DNSNames:   cert.DNSNames,
+		URIs:       uris,
+	}, nil
+}
+
+// ClientCertAuthenticationMiddleware 客户端证书（mTLS）认证中间件
+// 从已由 TLS 层校验的客户端证书中提取身份并写入用户声明，后续可直接使用 AuthorizationMiddleware 鉴权；
+// 证书本身可通过 GetClientCert 获取
+//
+// 参数：
+//   - mapper: 证书到用户声明的映射，为 nil 时使用 ClaimsFromClientCert
+//
+// 错误处理：
+//   - 非 TLS 连接或未提供证书 -> ErrUnauthorized
+//   - 映射失败 -> mapper 返回的错误
+//
+// 使用示例：
+//
+//	internal := router.Group("/internal",
+//	    abe.ClientCertAuthenticationMiddleware(nil),
+//	)
+//	internal.POST("/orders/sync", abe.AuthorizationMiddleware(engine, "/internal/orders", "write"), handler)
+//
+// 注意：
+//   - 需配置 server.tls.cert_file/key_file/client_ca，证书链校验由 TLS 握手完成，此中间件不重复校验
+//   - server.tls.client_auth=request 时未提供证书的连接也能建立，此中间件负责拒绝此类请求
+func ClientCertAuthenticationMiddleware(mapper func(cert *x509.Certificate) (UserTokenClaims, error)) gin.HandlerFunc {
+	if mapper == nil {
+		mapper = ClaimsFromClientCert
+	}
+	return func(ctx *gin.Context) {
+		state := ctx.Request.TLS
+		if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+			_ = ctx.Error(fmt.Errorf("缺少有效的客户端证书: %w", ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+		cert := state.VerifiedChains[0][0]
+
+		claims, err := mapper(cert)
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(contextKeyClientCert, cert)
+		ctx.Set(contextKeyUserClaims, claims)
+		ctx.Next()
+	}
+}
+
+// GetClientCert 获取经 ClientCertAuthenticationMiddleware 认证的客户端证书
+func GetClientCert(ctx *gin.Context) (*x509.Certificate, bool) {
+	v, ok := ctx.Get(contextKeyClientCert)
+	if !ok {
+		return nil, false
+	}
+	cert, ok := v.(*x509.Certificate)
+	return cert, ok
+}
@@ -0,0 +1,115 @@
+package abe
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Clock 时钟抽象
+// 框架中与时间相关的逻辑（令牌签发与过期校验、限流令牌补充等）通过 Clock 读取当前时间，
+// 测试中可替换为 ManualClock 以冻结或推进时间
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock 系统时钟，返回 time.Now()
+type SystemClock struct{}
+
+// Now 返回当前系统时间
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock 手动时钟，时间仅在调用 Set/Advance 时变化，适用于测试
+type ManualClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewManualClock 创建以 t 为当前时间的手动时钟
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t}
+}
+
+// Now 返回手动时钟的当前时间
+func (c *ManualClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Set 将当前时间设置为 t
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance 将当前时间推进 d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Clock 引擎时钟，未设置时为 SystemClock
+func (e *Engine) Clock() Clock {
+	if e.clock == nil {
+		return SystemClock{}
+	}
+	return e.clock
+}
+
+// SetClock 替换引擎时钟，应在引擎启动前调用；传 nil 恢复为系统时钟
+func (e *Engine) SetClock(c Clock) {
+	e.clock = c
+}
+
+// loadLocation 按顺序读取时区配置（IANA 名称，如 "Asia/Shanghai"），取第一个非空值；均未配置时返回 time.Local
+func loadLocation(cfg *viper.Viper, keys ...string) (*time.Location, error) {
+	for _, key := range keys {
+		name := strings.TrimSpace(cfg.GetString(key))
+		if name == "" {
+			continue
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s 时区无效：%w", key, err)
+		}
+		return loc, nil
+	}
+	return time.Local, nil
+}
+
+// Location 业务时区（app.timezone），未配置或无效时为本地时区
+func (e *Engine) Location() *time.Location {
+	e.locationOnce.Do(func() {
+		loc, err := loadLocation(e.config, "app.timezone")
+		if err != nil {
+			if e.logger != nil {
+				e.logger.Warn("业务时区配置无效，使用本地时区", "error", err)
+			}
+			loc = time.Local
+		}
+		e.location = loc
+	})
+	return e.location
+}
+
+// Now 返回业务时区下的当前时间（读取引擎时钟）
+func (e *Engine) Now() time.Time {
+	return e.Clock().Now().In(e.Location())
+}
+
+// FormatTime 按业务时区格式化时间
+//
+// 使用示例:
+//
+//	e.FormatTime(order.CreatedAt, time.DateTime) // "2024-05-01 08:00:00"（app.timezone=Asia/Shanghai）
+func (e *Engine) FormatTime(t time.Time, layout string) string {
+	return t.In(e.Location()).Format(layout)
+}
@@ -0,0 +1,105 @@
+package abe
+
+import (
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// conditionKind 条件参数的格式
+type conditionKind int
+
+const (
+	conditionPairs  conditionKind = iota // "Field value [Field value ...]"，如 required_if=Type company
+	conditionFields                      // "Field [Field ...]"，如 required_with=Phone Email
+)
+
+// conditionalTranslation 条件验证标签的翻译，{0} 为字段名，{1} 为条件描述
+type conditionalTranslation struct {
+	kind conditionKind
+	zh   string
+	en   string
+}
+
+// conditionalTranslations 内置条件验证标签的翻译
+// validator 自带翻译只提示"为必填字段"，不说明触发条件，这里覆盖为带条件的提示
+var conditionalTranslations = map[string]conditionalTranslation{
+	"required_if":          {conditionPairs, "当{1}时，{0}为必填字段", "{0} is required when {1}"},
+	"required_unless":      {conditionPairs, "除非{1}，否则{0}为必填字段", "{0} is required unless {1}"},
+	"required_with":        {conditionFields, "填写{1}中任一项时，{0}为必填字段", "{0} is required when any of {1} is present"},
+	"required_with_all":    {conditionFields, "{1}均已填写时，{0}为必填字段", "{0} is required when all of {1} are present"},
+	"required_without":     {conditionFields, "{1}中任一项未填写时，{0}为必填字段", "{0} is required when any of {1} is missing"},
+	"required_without_all": {conditionFields, "{1}均未填写时，{0}为必填字段", "{0} is required when none of {1} are present"},
+	"excluded_if":          {conditionPairs, "当{1}时，{0}必须为空", "{0} must be empty when {1}"},
+	"excluded_unless":      {conditionPairs, "除非{1}，否则{0}必须为空", "{0} must be empty unless {1}"},
+	"excluded_with":        {conditionFields, "填写{1}中任一项时，{0}必须为空", "{0} must be empty when any of {1} is present"},
+	"excluded_with_all":    {conditionFields, "{1}均已填写时，{0}必须为空", "{0} must be empty when all of {1} are present"},
+	"excluded_without":     {conditionFields, "{1}中任一项未填写时，{0}必须为空", "{0} must be empty when any of {1} is missing"},
+	"excluded_without_all": {conditionFields, "{1}均未填写时，{0}必须为空", "{0} must be empty when none of {1} are present"},
+}
+
+// NewConditionalRule 创建依赖其他字段取值的自定义验证规则
+// 与 NewValidationRule 的区别：
+//   - 字段为零值时同样执行验证函数（"条件满足时必填"类规则需要检查空值）
+//   - 翻译模板中的 {1} 渲染为条件描述而非原始参数：参数按 "字段 值 [字段 值 ...]" 解析，
+//     如参数 "Type company" 在中文下渲染为 "Type为company"，英文下为 "Type is company"
+//
+// 使用示例:
+//
+//	rule := abe.NewConditionalRule("tax_id_if", func(fl validator.FieldLevel) bool { ... }).
+//	    WithZhTranslation("当{1}时，{0}必须为有效税号").
+//	    WithEnTranslation("{0} must be a valid tax ID when {1}")
+//	engine.Validator().MustRegisterCustomRule(rule)
+//	// 使用：TaxID string `validate:"tax_id_if=Type company"`
+func NewConditionalRule(tag string, fn validator.Func) *ValidationRule {
+	r := NewValidationRule(tag, fn)
+	r.callEvenIfNull = true
+	r.conditional = true
+	return r
+}
+
+// registerConditionalTranslations 为内置条件验证标签注册带条件描述的翻译（覆盖 validator 默认翻译）
+func (v *Validator) registerConditionalTranslations(trans ut.Translator, locale string) {
+	for tag, t := range conditionalTranslations {
+		template := t.en
+		if locale == "zh" {
+			template = t.zh
+		}
+		v.registerConditionTranslation(trans, tag, template, locale, t.kind)
+	}
+}
+
+// registerConditionTranslation 注册条件翻译：{0} 为字段名，{1} 为按 kind 解析参数得到的条件描述
+// universal-translator 要求占位符按序出现，而中文模板常将条件置于字段名之前，因此直接替换占位符
+func (v *Validator) registerConditionTranslation(trans ut.Translator, tag, template, locale string, kind conditionKind) {
+	_ = v.instance.RegisterTranslation(tag, trans, func(ut ut.Translator) error {
+		return nil
+	}, func(_ ut.Translator, fe validator.FieldError) string {
+		return strings.NewReplacer("{0}", fe.Field(), "{1}", describeCondition(fe.Param(), locale, kind)).Replace(template)
+	})
+}
+
+// describeCondition 将条件参数渲染为可读描述
+func describeCondition(param, locale string, kind conditionKind) string {
+	parts := strings.Fields(param)
+	if kind == conditionFields {
+		if locale == "zh" {
+			return strings.Join(parts, "、")
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	is, and := " is ", " and "
+	if locale == "zh" {
+		is, and = "为", "且"
+	}
+	conds := make([]string, 0, len(parts)/2)
+	for i := 0; i+1 < len(parts); i += 2 {
+		conds = append(conds, parts[i]+is+parts[i+1])
+	}
+	if len(conds) == 0 {
+		return param
+	}
+	return strings.Join(conds, and)
+}
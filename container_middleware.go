@@ -1,6 +1,12 @@
 package abe
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do/v2"
 )
@@ -8,20 +14,109 @@ import (
 // doInjectorKey 为请求级 DI 容器在 gin.Context 中的键名
 const doInjectorKey = "abe.do_injector"
 
+// ContainerShutdownFailure 请求级容器关闭失败事件的消息体
+// 配置 container.shutdown_error_topic 后发布到事件总线，便于统计与告警
+type ContainerShutdownFailure struct {
+	RequestID string            `json:"request_id"`
+	Method    string            `json:"method"`
+	Path      string            `json:"path"`
+	Errors    map[string]string `json:"errors,omitempty"` // 服务名 -> 错误信息
+	Panic     string            `json:"panic,omitempty"`  // Shutdown 过程中发生的 panic
+	Duration  time.Duration     `json:"duration"`
+}
+
 // containerMiddleware 在每个请求开始时创建一个 do.Injector，并注册框架级依赖与请求级元信息。
-// 生命周期：在请求结束时统一执行 injector.Shutdown()，确保资源优雅释放。
+// 请求级容器额外提供 UserTokenClaims：未认证的请求获取时返回 ErrNotAuthenticated。
+// 生命周期：在请求结束时（包括处理器 panic 的情况）统一执行 injector.Shutdown()，确保资源优雅释放。
+//
+// 配置:
+//   - container.shutdown_timeout: Shutdown 超时时间（如 "5s"），默认不限制
+//   - container.shutdown_error_topic: 关闭失败时发布 ContainerShutdownFailure 事件的主题，默认不发布
+//
+// 关闭失败与 Shutdown 中的 panic 均以 Error 级别记录（附带请求 ID），不会影响已写出的响应。
 func containerMiddleware(engine *Engine) gin.HandlerFunc {
+	timeout := engine.Config().GetDuration("container.shutdown_timeout")
+	topic := engine.Config().GetString("container.shutdown_error_topic")
+
 	return func(ctx *gin.Context) {
 		requestScope := do.New()
 
 		engine.doPackage(requestScope)
 		do.ProvideValue(requestScope, GetRequestMeta(ctx))
+		// 认证中间件通常挂载在分组上、晚于本中间件执行，因此令牌声明在获取时才从上下文读取
+		do.Provide(requestScope, func(do.Injector) (UserTokenClaims, error) {
+			claims, ok := GetUserTokenClaims(ctx)
+			if !ok {
+				return nil, ErrNotAuthenticated
+			}
+			return claims, nil
+		})
+		engine.applyRequestProviders(ctx, requestScope)
 
 		ctx.Set(doInjectorKey, requestScope)
 
+		defer shutdownRequestScope(engine, ctx, requestScope, timeout, topic)
+
 		ctx.Next()
+	}
+}
 
-		_ = requestScope.Shutdown()
+// shutdownRequestScope 关闭请求级容器，记录失败并按需发布事件；Shutdown 中的 panic 会被恢复
+func shutdownRequestScope(engine *Engine, ctx *gin.Context, scope *do.RootScope, timeout time.Duration, topic string) {
+	start := time.Now()
+	failure := ContainerShutdownFailure{
+		RequestID: GetRequestID(ctx),
+		Method:    ctx.Request.Method,
+		Path:      ctx.FullPath(),
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				failure.Panic = fmt.Sprint(r)
+			}
+		}()
+
+		shutdownCtx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			shutdownCtx, cancel = context.WithTimeout(shutdownCtx, timeout)
+			defer cancel()
+		}
+		report := scope.ShutdownWithContext(shutdownCtx)
+		if report == nil || report.Succeed {
+			return
+		}
+		failure.Errors = make(map[string]string, len(report.Errors))
+		for svc, err := range report.Errors {
+			if err != nil {
+				failure.Errors[svc.Service] = err.Error()
+			}
+		}
+	}()
+	failure.Duration = time.Since(start)
+
+	if failure.Panic == "" && len(failure.Errors) == 0 {
+		return
+	}
+	engine.Logger().Error("请求级容器关闭失败",
+		"request_id", failure.RequestID,
+		"method", failure.Method,
+		"path", failure.Path,
+		"errors", failure.Errors,
+		"panic", failure.Panic,
+		"duration", failure.Duration,
+	)
+
+	if topic == "" || engine.EventBus() == nil {
+		return
+	}
+	payload, err := json.Marshal(failure)
+	if err != nil {
+		return
+	}
+	if err := engine.EventBus().Publish(topic, NewRequestMessage(ctx, payload)); err != nil {
+		engine.Logger().Warn("发布容器关闭失败事件失败", "topic", topic, "request_id", failure.RequestID, "error", err)
 	}
 }
 
@@ -31,7 +126,61 @@ func Injector(ctx *gin.Context) do.Injector {
 	return v.(do.Injector)
 }
 
+// ProvideUseCase 注册带构造逻辑的用例（根容器单例），应在 Run 之前调用
+// 构造函数在首次 Invoke 时以根容器执行，之后所有请求共享同一实例；
+// 因此只能依赖全局服务，不应保存请求级依赖（如 RequestMeta、TransactionMiddleware 中的事务连接），
+// 需要请求级依赖时使用 ProvideRequest。同一类型只能注册一次
+//
+// 使用示例:
+//
+//	abe.ProvideUseCase(e, func(i do.Injector) (*ReportUseCase, error) {
+//	    return NewReportUseCase(do.MustInvoke[*gorm.DB](i), 30*time.Second), nil
+//	})
+func ProvideUseCase[T any](e *Engine, constructor func(do.Injector) (T, error)) {
+	do.Provide(e.rootScope, constructor)
+	e.addRequestProvider(func(_ *gin.Context, scope do.Injector) {
+		do.Provide(scope, func(do.Injector) (T, error) {
+			return do.Invoke[T](e.rootScope)
+		})
+	})
+}
+
+// ProvideRequest 注册请求级服务，应在 Run 之前调用
+// 构造函数以请求级容器执行（可获取 RequestMeta、请求事务中的 *gorm.DB 等），
+// 在请求内首次获取时创建，同一请求内共享，请求结束时随容器关闭。同一类型只能注册一次
+//
+// 使用示例:
+//
+//	abe.ProvideRequest(e, func(ctx *gin.Context, i do.Injector) (*CreateOrderUseCase, error) {
+//	    return &CreateOrderUseCase{db: do.MustInvoke[*gorm.DB](i), requestID: abe.GetRequestID(ctx)}, nil
+//	})
+func ProvideRequest[T any](e *Engine, constructor func(ctx *gin.Context, i do.Injector) (T, error)) {
+	e.addRequestProvider(func(ctx *gin.Context, scope do.Injector) {
+		do.Provide(scope, func(i do.Injector) (T, error) {
+			return constructor(ctx, i)
+		})
+	})
+}
+
+func (e *Engine) addRequestProvider(fn func(ctx *gin.Context, scope do.Injector)) {
+	e.providersMu.Lock()
+	defer e.providersMu.Unlock()
+	e.requestProviders = append(e.requestProviders, fn)
+}
+
+// applyRequestProviders 将 ProvideUseCase、ProvideRequest 的注册应用到请求级容器
+func (e *Engine) applyRequestProviders(ctx *gin.Context, scope do.Injector) {
+	e.providersMu.Lock()
+	providers := slices.Clone(e.requestProviders)
+	e.providersMu.Unlock()
+	for _, provide := range providers {
+		provide(ctx, scope)
+	}
+}
+
 // Invoke 从 DI 容器中获取指定的 UseCase 实例，并执行其 Handle 方法。
+// 通过 ProvideUseCase、ProvideRequest 注册的用例使用注册的构造函数，
+// 其余用例按字段（do:"" 标签）从请求级容器注入。
 //
 // 参数:
 //   - ctx: *gin.Context，当前请求的上下文，用于获取 DI 容器。
@@ -41,10 +190,23 @@ func Injector(ctx *gin.Context) do.Injector {
 //   - error: 处理过程中遇到的错误，若成功则为 nil。
 func Invoke[T UseCase[R], R any](ctx *gin.Context) (R, error) {
 	injector := Injector(ctx)
-	useCase := do.MustInvokeStruct[T](injector)
+	var useCase T
+	if hasService[T](injector) {
+		useCase = do.MustInvoke[T](injector)
+	} else {
+		useCase = do.MustInvokeStruct[T](injector)
+	}
 	res, err := useCase.Handle(ctx)
 	if err != nil {
 		_ = ctx.Error(err)
 	}
 	return res, err
 }
+
+// hasService 判断容器中是否注册了类型 T 的服务
+func hasService[T any](injector do.Injector) bool {
+	name := do.NameOf[T]()
+	return slices.ContainsFunc(injector.ListProvidedServices(), func(s do.ServiceDescription) bool {
+		return s.Service == name
+	})
+}
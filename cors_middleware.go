@@ -2,36 +2,182 @@ package abe
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 )
 
+// corsPolicy 跨域策略快照，配置热更新时整体替换
+type corsPolicy struct {
+	allowedOrigins   []string
+	originPatterns   []*regexp.Regexp         // allow_origins 中 "re:" 前缀的正则，加载时预编译
+	originValidator  func(origin string) bool // 非 nil 时由其决定 Origin 是否允许，见 CORSMiddlewareWithValidator
+	allowedHeaders   []string
+	allowCredentials bool
+	maxAgeSeconds    int
+	methods          string
+	headers          string
+	expose           string
+}
+
+// CORSConfig 跨域策略配置，用于 CORSMiddlewareWith 为路由组设置独立策略；未设置的字段使用与 server.cors.* 相同的默认值
+type CORSConfig struct {
+	AllowOrigins     []string                 // 允许的来源：精确匹配、*.example.com、re:正则、"*"，默认 ["*"]
+	AllowOriginFunc  func(origin string) bool // 非 nil 时由其决定来源是否允许，AllowOrigins 不再生效
+	AllowMethods     []string                 // 默认 GET、POST、PUT、DELETE、OPTIONS
+	AllowHeaders     []string                 // 默认常用请求头（Content-Type、Authorization 等）
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration // 预检结果缓存时间，默认 24h
+}
+
+var (
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	defaultCORSHeaders = []string{"Content-Type", "Content-Length", "Accept", "Accept-Encoding", "Authorization", "Origin", "Cache-Control", "X-Requested-With"}
+)
+
+// loadCorsPolicy 从 server.cors.* 配置构建跨域策略，无效的正则来源记录告警后忽略
+func loadCorsPolicy(cfg *viper.Viper, logger *slog.Logger) *corsPolicy {
+	return newCorsPolicy(CORSConfig{
+		AllowOrigins:     getStringSlice(cfg, "server.cors.allow_origins", nil),
+		AllowMethods:     getStringSlice(cfg, "server.cors.allow_methods", nil),
+		AllowHeaders:     getStringSlice(cfg, "server.cors.allow_headers", nil),
+		ExposeHeaders:    getStringSlice(cfg, "server.cors.expose_headers", nil),
+		AllowCredentials: cfg.GetBool("server.cors.allow_credentials"),
+		MaxAge:           time.Duration(cfg.GetInt("server.cors.max_age_seconds")) * time.Second,
+	}, logger)
+}
+
+// newCorsPolicy 按配置构建跨域策略，未设置的字段使用默认值
+func newCorsPolicy(cfg CORSConfig, logger *slog.Logger) *corsPolicy {
+	allowedOrigins := cfg.AllowOrigins
+	if len(allowedOrigins) == 0 {
+		allowedOrigins = []string{"*"}
+	}
+	allowedMethods := cfg.AllowMethods
+	if len(allowedMethods) == 0 {
+		allowedMethods = defaultCORSMethods
+	}
+	allowedHeaders := cfg.AllowHeaders
+	if len(allowedHeaders) == 0 {
+		allowedHeaders = defaultCORSHeaders
+	}
+	maxAgeSeconds := int(cfg.MaxAge.Seconds())
+	if maxAgeSeconds <= 0 {
+		maxAgeSeconds = int((24 * time.Hour).Seconds())
+	}
+
+	origins, patterns := splitOriginPatterns(allowedOrigins, logger)
+
+	return &corsPolicy{
+		allowedOrigins:   origins,
+		originPatterns:   patterns,
+		originValidator:  cfg.AllowOriginFunc,
+		allowedHeaders:   allowedHeaders,
+		allowCredentials: cfg.AllowCredentials,
+		maxAgeSeconds:    maxAgeSeconds,
+		methods:          strings.Join(allowedMethods, ", "),
+		headers:          strings.Join(allowedHeaders, ", "),
+		expose:           strings.Join(cfg.ExposeHeaders, ", "),
+	}
+}
+
 // corsMiddleware 基于配置的跨域中间件
 // 设计要点：
-// - 支持域名白名单（含通配 *.example.com）与 "*"；当允许凭证时，自动避免 "*"，改为回显匹配的 Origin
+// - 支持域名白名单（含通配 *.example.com、正则 re:^https://pr-\d+\.preview\.example\.com$）与 "*"；当允许凭证时，自动避免 "*"，改为回显匹配的 Origin
 // - 预检请求（OPTIONS）直接 204 返回并携带 CORS 头，避免触达业务处理器
 // - 方法/头/暴露头/凭证/缓存时间均可配置；未配置时使用合理默认值
-// - 与 abe 的中间件管理配合：通过 Engine.MiddlewareManager().RegisterGlobal(corsMiddleware(engine.Config())) 注册到 "/api" 分组
-func corsMiddleware(cfg *viper.Viper) gin.HandlerFunc {
-	allowedOrigins := getStringSlice(cfg, "server.cors.allow_origins", []string{"*"})
-	allowedMethods := getStringSlice(cfg, "server.cors.allow_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
-	allowedHeaders := getStringSlice(cfg, "server.cors.allow_headers", []string{"Content-Type", "Content-Length", "Accept", "Accept-Encoding", "Authorization", "Origin", "Cache-Control", "X-Requested-With"})
-	exposeHeaders := getStringSlice(cfg, "server.cors.expose_headers", nil)
-	allowCredentials := cfg.GetBool("server.cors.allow_credentials")
-	maxAgeSeconds := cfg.GetInt("server.cors.max_age_seconds")
-	if maxAgeSeconds <= 0 {
-		maxAgeSeconds = int((24 * time.Hour).Seconds())
+// - server.cors.* 配置文件变更后自动生效，无需重启
+// - 由引擎在挂载控制器时作为首个全局中间件注册；server.cors.enabled=false 时不注册
+func corsMiddleware(e *Engine) gin.HandlerFunc {
+	return newCORSMiddleware(e, nil)
+}
+
+// CORSMiddlewareWithValidator 由回调动态决定 Origin 是否允许的跨域中间件（如基于数据库的白名单）
+// 方法、头、凭证、缓存时间等仍取自 server.cors.*（支持热更新），server.cors.allow_origins 不再生效；
+// 允许时总是回显请求的 Origin，不会返回 "*"
+// 作为全局中间件替换内置跨域处理时，需设置 server.cors.enabled=false
+//
+// 使用示例:
+//
+//	engine.MiddlewareManager().RegisterGlobal(abe.CORSMiddlewareWithValidator(engine, func(origin string) bool {
+//	    return tenantOrigins.Contains(origin) // 回调会在每个跨域请求上调用，应自行缓存
+//	}))
+func CORSMiddlewareWithValidator(e *Engine, validate func(origin string) bool) gin.HandlerFunc {
+	return newCORSMiddleware(e, validate)
+}
+
+// newCORSMiddleware 基于 server.cors.* 构建跨域中间件，配置变更时替换策略快照
+func newCORSMiddleware(e *Engine, validate func(origin string) bool) gin.HandlerFunc {
+	load := func() *corsPolicy {
+		p := loadCorsPolicy(e.Config(), e.Logger())
+		p.originValidator = validate
+		return p
 	}
+	var policy atomic.Pointer[corsPolicy]
+	policy.Store(load())
+	e.OnConfigChange(func(key string) {
+		if strings.HasPrefix(key, "server.cors.") {
+			policy.Store(load())
+		}
+	})
+	handle := corsHandler(policy.Load)
 
-	methods := strings.Join(allowedMethods, ", ")
-	headers := strings.Join(allowedHeaders, ", ")
-	expose := strings.Join(exposeHeaders, ", ")
+	// 路由链中含 CORSMiddlewareWith 时交由分组策略处理（含预检），按方法与路由模板缓存判断结果
+	var groupRoutes sync.Map
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Origin") != "" && ctx.FullPath() != "" {
+			key := ctx.Request.Method + " " + ctx.FullPath()
+			v, ok := groupRoutes.Load(key)
+			if !ok {
+				v = slices.Contains(ctx.HandlerNames(), groupCORSHandlerName())
+				groupRoutes.Store(key, v)
+			}
+			if v.(bool) {
+				ctx.Next()
+				return
+			}
+		}
+		handle(ctx)
+	}
+}
 
+// CORSMiddlewareWith 按 cfg 构建独立于 server.cors.* 的跨域中间件，用于为路由组设置不同策略（如更严格的管理端接口）
+// 路由链中含该中间件时，全局跨域中间件不再处理该路由，预检请求由分组策略直接返回 204
+// 注意：预检请求需要匹配到路由才会进入分组中间件链，可为分组注册 OPTIONS 路由（如 admin.OPTIONS("/*path", abe.NoContent)）
+//
+// 使用示例:
+//
+//	admin := rg.Group("/admin", abe.CORSMiddlewareWith(abe.CORSConfig{
+//	    AllowOrigins:     []string{"https://admin.example.com"},
+//	    AllowCredentials: true,
+//	}))
+func CORSMiddlewareWith(cfg CORSConfig) gin.HandlerFunc {
+	p := newCorsPolicy(cfg, slog.Default())
+	handle := corsHandler(func() *corsPolicy { return p })
+	// 独立的函数字面量，供全局跨域中间件通过处理器名称识别分组策略
+	return func(ctx *gin.Context) {
+		handle(ctx)
+	}
+}
+
+// groupCORSHandlerName CORSMiddlewareWith 返回的处理器名称
+var groupCORSHandlerName = sync.OnceValue(func() string {
+	return runtime.FuncForPC(reflect.ValueOf(CORSMiddlewareWith(CORSConfig{})).Pointer()).Name()
+})
+
+// corsHandler 按策略处理跨域请求，每个请求通过 load 获取当前策略
+func corsHandler(load func() *corsPolicy) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		origin := ctx.GetHeader("Origin")
 
@@ -41,11 +187,15 @@ func corsMiddleware(cfg *viper.Viper) gin.HandlerFunc {
 			return
 		}
 
+		p := load()
+		allowedHeaders, allowCredentials := p.allowedHeaders, p.allowCredentials
+		maxAgeSeconds, methods, headers, expose := p.maxAgeSeconds, p.methods, p.headers, p.expose
+
 		// 计算允许的 Origin 值
 		var allowOrigin string
-		if contains(allowedOrigins, "*") && !allowCredentials {
+		if p.originValidator == nil && contains(p.allowedOrigins, "*") && !allowCredentials {
 			allowOrigin = "*"
-		} else if originAllowed(origin, allowedOrigins) {
+		} else if p.allows(origin) {
 			// 当允许凭证或未使用 "*"，严格回显匹配到的 origin
 			allowOrigin = origin
 		}
@@ -53,6 +203,9 @@ func corsMiddleware(cfg *viper.Viper) gin.HandlerFunc {
 		// 设置通用 CORS 响应头（仅在命中策略时）
 		if allowOrigin != "" {
 			ctx.Header("Access-Control-Allow-Origin", allowOrigin)
+			if allowOrigin != "*" {
+				ctx.Writer.Header().Add("Vary", "Origin")
+			}
 			if allowCredentials {
 				ctx.Header("Access-Control-Allow-Credentials", "true")
 			}
@@ -85,6 +238,44 @@ func corsMiddleware(cfg *viper.Viper) gin.HandlerFunc {
 	}
 }
 
+// allows 判断 Origin 是否被策略允许：设置了回调时仅由回调决定，否则依次匹配白名单与正则
+func (p *corsPolicy) allows(origin string) bool {
+	if p.originValidator != nil {
+		return p.originValidator(origin)
+	}
+	if originAllowed(origin, p.allowedOrigins) {
+		return true
+	}
+	for _, re := range p.originPatterns {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitOriginPatterns 将 "re:" 前缀的来源编译为正则，其余原样返回；编译失败的条目记录告警后忽略
+func splitOriginPatterns(origins []string, logger *slog.Logger) ([]string, []*regexp.Regexp) {
+	plain := make([]string, 0, len(origins))
+	var patterns []*regexp.Regexp
+	for _, o := range origins {
+		expr, ok := strings.CutPrefix(strings.TrimSpace(o), "re:")
+		if !ok {
+			plain = append(plain, o)
+			continue
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			if logger != nil {
+				logger.Warn("跨域来源正则无效，已忽略", "pattern", expr, "error", err)
+			}
+			continue
+		}
+		patterns = append(patterns, re)
+	}
+	return plain, patterns
+}
+
 // getStringSlice 读取字符串切片配置，支持逗号分隔的字符串与原生切片
 func getStringSlice(cfg *viper.Viper, key string, defaults []string) []string {
 	v := cfg.Get(key)
@@ -138,6 +329,7 @@ func contains(xs []string, s string) bool {
 // - 精确匹配（大小写不敏感）
 // - 通配符前缀 "*.example.com"（大小写不敏感，按后缀匹配）
 // - 全匹配 "*"（已在上游处理）
+// 正则条目（re:）由 corsPolicy.allows 单独匹配
 func originAllowed(origin string, allowed []string) bool {
 	lo := strings.ToLower(origin)
 	for _, a := range allowed {
@@ -0,0 +1,185 @@
+package abe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultCronLockTable 数据库定时任务锁默认表名
+const defaultCronLockTable = "cron_locks"
+
+// CronLocker 定时任务分布式锁
+// 多副本部署时，同一任务在每个副本上都会触发，仅获得锁的副本实际执行
+type CronLocker interface {
+	// TryLock 尝试获取锁；锁被其他持有者占用且未过期时返回 false
+	TryLock(name string, ttl time.Duration) (bool, error)
+	// Unlock 释放当前持有者持有的锁，锁不存在或非本持有者时忽略
+	Unlock(name string)
+}
+
+// CronJobOption 具名定时任务选项
+type CronJobOption func(*cronJobOptions)
+
+type cronJobOptions struct {
+	lockName string
+	lockTTL  time.Duration
+}
+
+// WithCronLock 为定时任务加分布式锁，保证多副本中每个调度周期仅一个副本执行
+//
+// 参数:
+//   - name: 锁名，通常与任务名一致
+//   - ttl: 锁有效期，应长于任务执行时间与副本间时钟偏差之和，并短于调度间隔
+//
+// 任务结束后不主动释放锁，锁在 ttl 到期后自动失效，避免时钟较慢的副本在同一周期内再次执行。
+//
+// 使用示例:
+//
+//	_, err := e.AddCronJob("report", "0 0 2 * * *", buildReport, abe.WithCronLock("report", 10*time.Minute))
+func WithCronLock(name string, ttl time.Duration) CronJobOption {
+	return func(o *cronJobOptions) {
+		o.lockName = name
+		o.lockTTL = ttl
+	}
+}
+
+// CronLocker 定时任务锁（懒加载）
+// 按 cron.lock.store 配置创建：database（默认，使用 cron.lock.table 表）或 memory（仅单实例有效）
+func (e *Engine) CronLocker() CronLocker {
+	e.cronLockerOnce.Do(func() {
+		if e.cronLocker == nil {
+			e.cronLocker = newCronLocker(e)
+		}
+	})
+	return e.cronLocker
+}
+
+// SetCronLocker 替换定时任务锁实现（如基于 Redis），应在添加带锁任务前调用
+func (e *Engine) SetCronLocker(locker CronLocker) {
+	e.cronLockerOnce.Do(func() {})
+	e.cronLocker = locker
+}
+
+// newCronLocker 按配置创建定时任务锁，数据库不可用时回退为内存锁
+func newCronLocker(e *Engine) CronLocker {
+	cfg := e.Config()
+	if cfg.GetString("cron.lock.store") == "memory" {
+		return NewMemoryCronLocker()
+	}
+	table := cfg.GetString("cron.lock.table")
+	if table == "" {
+		table = defaultCronLockTable
+	}
+	locker, err := NewGormCronLocker(e.DB(), table)
+	if err != nil {
+		e.Logger().Error("初始化数据库定时任务锁失败，回退为内存锁（多副本下无法互斥）", "table", table, "error", err)
+		return NewMemoryCronLocker()
+	}
+	return locker
+}
+
+// lockedCronJob 包装任务函数：仅在获得锁时执行
+func (e *Engine) lockedCronJob(jobName, lockName string, ttl time.Duration, fn func()) func() {
+	return func() {
+		acquired, err := e.CronLocker().TryLock(lockName, ttl)
+		if err != nil {
+			e.logger.Error("获取定时任务锁失败，跳过本次执行", "name", jobName, "lock", lockName, "error", err)
+			return
+		}
+		if !acquired {
+			e.logger.Debug("定时任务锁被其他实例持有，跳过本次执行", "name", jobName, "lock", lockName)
+			return
+		}
+		fn()
+	}
+}
+
+// cronLockOwner 生成当前进程的锁持有者标识
+func cronLockOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), watermill.NewShortUUID())
+}
+
+// cronLockRecord 定时任务锁的数据库模型
+type cronLockRecord struct {
+	Name      string    `gorm:"primaryKey;size:191"`
+	Owner     string    `gorm:"size:191;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// gormCronLocker 基于数据库唯一键的定时任务锁
+type gormCronLocker struct {
+	db    *gorm.DB
+	table string
+	owner string
+}
+
+// NewGormCronLocker 创建数据库定时任务锁，并自动迁移锁表
+// 加锁依赖主键唯一约束：先清理过期锁，再以"冲突时不做处理"的方式插入，插入成功即获得锁
+func NewGormCronLocker(db *gorm.DB, table string) (CronLocker, error) {
+	if db == nil {
+		return nil, errors.New("数据库未初始化")
+	}
+	if table == "" {
+		table = defaultCronLockTable
+	}
+	if err := db.Table(table).AutoMigrate(&cronLockRecord{}); err != nil {
+		return nil, fmt.Errorf("迁移定时任务锁表失败：%w", err)
+	}
+	return &gormCronLocker{db: db, table: table, owner: cronLockOwner()}, nil
+}
+
+func (l *gormCronLocker) TryLock(name string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	if err := l.db.Table(l.table).Where("name = ? AND expires_at < ?", name, now).Delete(&cronLockRecord{}).Error; err != nil {
+		return false, err
+	}
+	res := l.db.Table(l.table).Clauses(clause.OnConflict{DoNothing: true}).Create(&cronLockRecord{
+		Name:      name,
+		Owner:     l.owner,
+		ExpiresAt: now.Add(ttl),
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (l *gormCronLocker) Unlock(name string) {
+	_ = l.db.Table(l.table).Where("name = ? AND owner = ?", name, l.owner).Delete(&cronLockRecord{}).Error
+}
+
+// memoryCronLocker 进程内定时任务锁，仅用于单实例或测试
+type memoryCronLocker struct {
+	mu    sync.Mutex
+	locks map[string]time.Time // 锁名 -> 过期时间
+}
+
+// NewMemoryCronLocker 创建进程内定时任务锁
+func NewMemoryCronLocker() CronLocker {
+	return &memoryCronLocker{locks: make(map[string]time.Time)}
+}
+
+func (l *memoryCronLocker) TryLock(name string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if exp, ok := l.locks[name]; ok && now.Before(exp) {
+		return false, nil
+	}
+	l.locks[name] = now.Add(ttl)
+	return true, nil
+}
+
+func (l *memoryCronLocker) Unlock(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.locks, name)
+}
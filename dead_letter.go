@@ -0,0 +1,347 @@
+package abe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ErrDeadLetterNotFound 死信记录不存在
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// 死信存储默认值
+const (
+	defaultDeadLetterCapacity = 1000                 // 内存存储默认容量
+	defaultDeadLetterTable    = "event_dead_letters" // 数据库存储默认表名
+)
+
+// DeadLetter 死信记录：处理失败（重试耗尽）的事件消息
+type DeadLetter struct {
+	ID       string            `json:"id"`       // 记录 ID（原消息 UUID）
+	Topic    string            `json:"topic"`    // 原始主题，重放时发布到此主题
+	Payload  []byte            `json:"payload"`  // 原始消息载荷
+	Metadata map[string]string `json:"metadata"` // 原始消息元数据
+	Error    string            `json:"error"`    // 最后一次处理失败的错误信息
+	Attempts int               `json:"attempts"` // 已尝试处理次数
+	FailedAt time.Time         `json:"failed_at"`
+}
+
+// DeadLetterStore 死信存储接口
+// 框架提供内存（默认）与数据库两种实现，可通过 Engine.SetDeadLetterStore 替换
+type DeadLetterStore interface {
+	// Save 保存死信记录，ID 相同时覆盖
+	Save(ctx context.Context, dl DeadLetter) error
+	// List 按失败时间倒序列出死信记录；topic 为空表示全部主题，limit <= 0 表示不限制
+	List(ctx context.Context, topic string, limit int) ([]DeadLetter, error)
+	// Get 获取指定死信记录，不存在时返回 ErrDeadLetterNotFound
+	Get(ctx context.Context, id string) (DeadLetter, error)
+	// Delete 删除指定死信记录，不存在时不报错
+	Delete(ctx context.Context, id string) error
+}
+
+// newDeadLetterStore 按 event.dead_letter.store 配置创建死信存储
+// - memory（默认）：进程内存储，容量由 event.dead_letter.capacity 控制
+// - database：使用 GORM 持久化到 event.dead_letter.table 表
+func newDeadLetterStore(e *Engine) DeadLetterStore {
+	cfg := e.Config()
+	switch cfg.GetString("event.dead_letter.store") {
+	case "database":
+		table := cfg.GetString("event.dead_letter.table")
+		if table == "" {
+			table = defaultDeadLetterTable
+		}
+		store, err := NewGormDeadLetterStore(e.DB(), table)
+		if err != nil {
+			e.Logger().Error("初始化数据库死信存储失败，回退为内存存储", "table", table, "error", err)
+			return NewMemoryDeadLetterStore(cfg.GetInt("event.dead_letter.capacity"))
+		}
+		return store
+	default:
+		return NewMemoryDeadLetterStore(cfg.GetInt("event.dead_letter.capacity"))
+	}
+}
+
+// DeadLetters 死信存储（懒加载）
+func (e *Engine) DeadLetters() DeadLetterStore {
+	e.deadLettersOnce.Do(func() {
+		if e.deadLetters == nil {
+			e.deadLetters = newDeadLetterStore(e)
+		}
+	})
+	return e.deadLetters
+}
+
+// SetDeadLetterStore 替换死信存储实现，应在引擎启动前调用
+func (e *Engine) SetDeadLetterStore(store DeadLetterStore) {
+	e.deadLettersOnce.Do(func() {})
+	e.deadLetters = store
+}
+
+// CaptureDeadLetter 将处理失败的消息记录到死信存储
+//
+// 参数:
+//   - topic: 消息的原始主题
+//   - msg: 处理失败的消息
+//   - cause: 最后一次处理失败的错误
+//   - attempts: 已尝试处理次数
+func (e *Engine) CaptureDeadLetter(topic string, msg *EventMessage, cause error, attempts int) error {
+	dl := DeadLetter{
+		ID:       msg.UUID(),
+		Topic:    topic,
+		Payload:  append([]byte(nil), msg.Payload()...),
+		Metadata: make(map[string]string, len(msg.msg.Metadata)),
+		Attempts: attempts,
+		FailedAt: time.Now(),
+	}
+	for k, v := range msg.msg.Metadata {
+		dl.Metadata[k] = v
+	}
+	if cause != nil {
+		dl.Error = cause.Error()
+	}
+	if err := e.DeadLetters().Save(context.Background(), dl); err != nil {
+		return fmt.Errorf("保存死信记录失败：%w", err)
+	}
+	e.Logger().Warn("事件消息进入死信存储", "topic", topic, "message_uuid", dl.ID, "attempts", attempts, "error", dl.Error)
+	return nil
+}
+
+// ReplayDeadLetter 将死信记录重新发布到原始主题，成功后从死信存储中删除
+// 重放的消息使用新的 UUID，保留原始元数据
+func (e *Engine) ReplayDeadLetter(ctx context.Context, id string) error {
+	store := e.DeadLetters()
+	dl, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	msg := NewMessage(dl.Payload)
+	for k, v := range dl.Metadata {
+		msg.SetMetadata(k, v)
+	}
+	if err := e.EventBus().Publish(dl.Topic, msg); err != nil {
+		return fmt.Errorf("重放死信消息失败：%w", err)
+	}
+	if err := store.Delete(ctx, id); err != nil {
+		return fmt.Errorf("删除已重放的死信记录失败：%w", err)
+	}
+	e.Logger().Info("死信消息已重放", "topic", dl.Topic, "dead_letter_id", id, "message_uuid", msg.UUID())
+	return nil
+}
+
+// DeadLetterListHandler 死信列表处理器（管理接口，需自行挂载并做好鉴权）
+// 查询参数：topic（可选）、limit（可选，默认 100）
+func DeadLetterListHandler(e *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+		if err != nil {
+			limit = 100
+		}
+		list, err := e.DeadLetters().List(ctx.Request.Context(), ctx.Query("topic"), limit)
+		if err != nil {
+			_ = ctx.Error(fmt.Errorf("查询死信记录失败: %w", err))
+			ctx.Abort()
+			return
+		}
+		ctx.JSON(http.StatusOK, Response[[]DeadLetter]{Msg: "ok", Data: list})
+	}
+}
+
+// DeadLetterReplayHandler 死信重放处理器（管理接口，需自行挂载并做好鉴权）
+// 路由参数：id（死信记录 ID），例如 POST /admin/dead-letters/:id/replay；记录不存在时通过 ctx.Error 传递包装 ErrResourceNotFound 的错误，默认渲染为 404
+func DeadLetterReplayHandler(e *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := e.ReplayDeadLetter(ctx.Request.Context(), ctx.Param("id"))
+		if errors.Is(err, ErrDeadLetterNotFound) {
+			_ = ctx.Error(fmt.Errorf("死信记录不存在（%s）: %w: %w", ctx.Param("id"), err, ErrResourceNotFound))
+			ctx.Abort()
+			return
+		}
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		ctx.JSON(http.StatusOK, Response[any]{Msg: "ok"})
+	}
+}
+
+// memoryDeadLetterStore 基于内存的死信存储，超出容量时淘汰最早的记录
+type memoryDeadLetterStore struct {
+	mu       sync.RWMutex
+	capacity int
+	items    []DeadLetter // 按保存顺序排列
+}
+
+// NewMemoryDeadLetterStore 创建内存死信存储
+// capacity <= 0 时使用默认容量 1000
+func NewMemoryDeadLetterStore(capacity int) DeadLetterStore {
+	if capacity <= 0 {
+		capacity = defaultDeadLetterCapacity
+	}
+	return &memoryDeadLetterStore{capacity: capacity}
+}
+
+func (s *memoryDeadLetterStore) Save(_ context.Context, dl DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.items {
+		if s.items[i].ID == dl.ID {
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			break
+		}
+	}
+	s.items = append(s.items, dl)
+	if over := len(s.items) - s.capacity; over > 0 {
+		s.items = append([]DeadLetter(nil), s.items[over:]...)
+	}
+	return nil
+}
+
+func (s *memoryDeadLetterStore) List(_ context.Context, topic string, limit int) ([]DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]DeadLetter, 0)
+	for i := len(s.items) - 1; i >= 0; i-- {
+		if topic != "" && s.items[i].Topic != topic {
+			continue
+		}
+		out = append(out, s.items[i])
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *memoryDeadLetterStore) Get(_ context.Context, id string) (DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, dl := range s.items {
+		if dl.ID == id {
+			return dl, nil
+		}
+	}
+	return DeadLetter{}, ErrDeadLetterNotFound
+}
+
+func (s *memoryDeadLetterStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.items {
+		if s.items[i].ID == id {
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// deadLetterRecord 死信记录的数据库模型
+type deadLetterRecord struct {
+	ID       string    `gorm:"primaryKey;size:64"`
+	Topic    string    `gorm:"size:255;index"`
+	Payload  []byte    `gorm:"type:blob"`
+	Metadata string    `gorm:"type:text"`
+	Error    string    `gorm:"type:text"`
+	Attempts int       `gorm:"not null;default:0"`
+	FailedAt time.Time `gorm:"index"`
+}
+
+// gormDeadLetterStore 基于 GORM 的死信存储
+type gormDeadLetterStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormDeadLetterStore 创建数据库死信存储，并自动迁移死信表
+func NewGormDeadLetterStore(db *gorm.DB, table string) (DeadLetterStore, error) {
+	if db == nil {
+		return nil, errors.New("数据库未初始化")
+	}
+	if table == "" {
+		table = defaultDeadLetterTable
+	}
+	if err := db.Table(table).AutoMigrate(&deadLetterRecord{}); err != nil {
+		return nil, fmt.Errorf("迁移死信表失败：%w", err)
+	}
+	return &gormDeadLetterStore{db: db, table: table}, nil
+}
+
+func (s *gormDeadLetterStore) Save(ctx context.Context, dl DeadLetter) error {
+	meta, err := json.Marshal(dl.Metadata)
+	if err != nil {
+		return err
+	}
+	if dl.ID == "" {
+		dl.ID = watermill.NewUUID()
+	}
+	rec := deadLetterRecord{
+		ID:       dl.ID,
+		Topic:    dl.Topic,
+		Payload:  dl.Payload,
+		Metadata: string(meta),
+		Error:    dl.Error,
+		Attempts: dl.Attempts,
+		FailedAt: dl.FailedAt,
+	}
+	return s.db.WithContext(ctx).Table(s.table).Save(&rec).Error
+}
+
+func (s *gormDeadLetterStore) List(ctx context.Context, topic string, limit int) ([]DeadLetter, error) {
+	q := s.db.WithContext(ctx).Table(s.table).Order("failed_at DESC")
+	if topic != "" {
+		q = q.Where("topic = ?", topic)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	var recs []deadLetterRecord
+	if err := q.Find(&recs).Error; err != nil {
+		return nil, err
+	}
+	out := make([]DeadLetter, 0, len(recs))
+	for _, rec := range recs {
+		out = append(out, rec.toDeadLetter())
+	}
+	return out, nil
+}
+
+func (s *gormDeadLetterStore) Get(ctx context.Context, id string) (DeadLetter, error) {
+	var rec deadLetterRecord
+	err := s.db.WithContext(ctx).Table(s.table).Where("id = ?", id).First(&rec).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DeadLetter{}, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return DeadLetter{}, err
+	}
+	return rec.toDeadLetter(), nil
+}
+
+func (s *gormDeadLetterStore) Delete(ctx context.Context, id string) error {
+	return s.db.WithContext(ctx).Table(s.table).Where("id = ?", id).Delete(&deadLetterRecord{}).Error
+}
+
+// toDeadLetter 将数据库模型转换为死信记录
+func (r deadLetterRecord) toDeadLetter() DeadLetter {
+	dl := DeadLetter{
+		ID:       r.ID,
+		Topic:    r.Topic,
+		Payload:  r.Payload,
+		Error:    r.Error,
+		Attempts: r.Attempts,
+		FailedAt: r.FailedAt,
+	}
+	_ = json.Unmarshal([]byte(r.Metadata), &dl.Metadata)
+	return dl
+}
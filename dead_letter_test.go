@@ -0,0 +1,156 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// newEventTestEngine 创建使用进程内总线与内存死信存储的引擎
+func newEventTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	e := &Engine{
+		config: viper.New(),
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		events: newGoChannelBus(nil, nil),
+	}
+	t.Cleanup(func() { _ = e.events.close() })
+	return e
+}
+
+// waitDeadLetters 等待死信存储中出现 n 条指定主题的记录
+func waitDeadLetters(t *testing.T, e *Engine, topic string, n int) []DeadLetter {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		list, err := e.DeadLetters().List(context.Background(), topic, 0)
+		if err != nil {
+			t.Fatalf("查询死信记录失败：%v", err)
+		}
+		if len(list) >= n {
+			return list
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("死信记录数 = %d，期望 %d", len(list), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRetryExhaustionCapturesDeadLetter(t *testing.T) {
+	e := newEventTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerErr := errors.New("下游不可用")
+	err := e.SubscribeFunc(ctx, "order.created", func(context.Context, *EventMessage) error {
+		return handlerErr
+	}, WithRetry(2, time.Millisecond))
+	if err != nil {
+		t.Fatalf("订阅失败：%v", err)
+	}
+
+	msg := NewMessage([]byte(`{"id":1}`))
+	if err := e.EventBus().Publish("order.created", msg); err != nil {
+		t.Fatalf("发布失败：%v", err)
+	}
+
+	dl := waitDeadLetters(t, e, "order.created", 1)[0]
+	if dl.ID != msg.UUID() || dl.Attempts != 2 || dl.Error != handlerErr.Error() || string(dl.Payload) != `{"id":1}` {
+		t.Fatalf("死信记录 = %+v", dl)
+	}
+}
+
+func TestDeadLetterReplayHandler(t *testing.T) {
+	e := newEventTestEngine(t)
+	var got error
+	capture := func(ctx *gin.Context) {
+		ctx.Next()
+		if last := ctx.Errors.Last(); last != nil {
+			got = last.Err
+		}
+	}
+	r := newErrorTestRouter(capture)
+	r.POST("/dead-letters/:id/replay", DeadLetterReplayHandler(e))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/dead-letters/missing/replay", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("记录不存在时状态码 = %d，期望 404", w.Code)
+	}
+	if !errors.Is(got, ErrDeadLetterNotFound) || !errors.Is(got, ErrResourceNotFound) {
+		t.Fatalf("错误链应包含 ErrDeadLetterNotFound 与 ErrResourceNotFound，实际 %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := e.EventBus().Subscribe(ctx, "order.created")
+	if err != nil {
+		t.Fatalf("订阅失败：%v", err)
+	}
+	msg := NewMessage([]byte(`{"id":1}`))
+	if err := e.CaptureDeadLetter("order.created", msg, errors.New("失败"), 3); err != nil {
+		t.Fatalf("写入死信失败：%v", err)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/dead-letters/"+msg.UUID()+"/replay", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("重放状态码 = %d，期望 200（响应：%s）", w.Code, w.Body.String())
+	}
+	select {
+	case replayed := <-ch:
+		replayed.Ack()
+		if string(replayed.Payload()) != `{"id":1}` {
+			t.Fatalf("重放载荷 = %s", replayed.Payload())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("未收到重放的消息")
+	}
+	if _, err := e.DeadLetters().Get(context.Background(), msg.UUID()); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Fatalf("重放后死信记录仍存在：%v", err)
+	}
+}
+
+func TestWithDeadLetterCapturesAndForwards(t *testing.T) {
+	e := newEventTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dlq, err := e.EventBus().Subscribe(ctx, "order.created.dlq")
+	if err != nil {
+		t.Fatalf("订阅死信主题失败：%v", err)
+	}
+	err = e.SubscribeFunc(ctx, "order.created", func(context.Context, *EventMessage) error {
+		return errors.New("下游不可用")
+	}, WithDeadLetter("order.created.dlq"))
+	if err != nil {
+		t.Fatalf("订阅失败：%v", err)
+	}
+
+	msg := NewMessage([]byte(`{"id":2}`))
+	if err := e.EventBus().Publish("order.created", msg); err != nil {
+		t.Fatalf("发布失败：%v", err)
+	}
+
+	select {
+	case forwarded := <-dlq:
+		forwarded.Ack()
+		if got := forwarded.Metadata(DeadLetterTopicMetadataKey); got != "order.created" {
+			t.Fatalf("死信主题元数据 = %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("未收到转发到死信主题的消息")
+	}
+	if dl := waitDeadLetters(t, e, "order.created", 1)[0]; dl.ID != msg.UUID() || dl.Attempts != 1 {
+		t.Fatalf("死信记录 = %+v", dl)
+	}
+}
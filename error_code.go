@@ -0,0 +1,116 @@
+package abe
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Coded 携带业务错误码的错误
+// 实现该接口的错误由 CodedErrorHandler 按错误码注册表映射 HTTP 状态码
+type Coded interface {
+	error
+	ErrorCode() ErrorCode
+}
+
+// errorCodeEntry 错误码注册信息
+type errorCodeEntry struct {
+	status  int
+	message string
+}
+
+var (
+	errorCodesMu sync.RWMutex
+	errorCodes   = map[ErrorCode]errorCodeEntry{}
+)
+
+// RegisterErrorCode 注册业务错误码对应的 HTTP 状态码与默认消息，重复注册时覆盖；应在启动阶段调用
+// 默认消息可包含 fmt 占位符，由 Errorf 的参数填充
+//
+// 使用示例:
+//
+//	const ErrCodeOrderNotFound abe.ErrorCode = 40401
+//
+//	func init() {
+//	    abe.RegisterErrorCode(ErrCodeOrderNotFound, http.StatusNotFound, "订单 %s 不存在")
+//	}
+func RegisterErrorCode(code ErrorCode, status int, defaultMessage string) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	errorCodes[code] = errorCodeEntry{status: status, message: defaultMessage}
+}
+
+// lookupErrorCode 查询错误码注册信息
+func lookupErrorCode(code ErrorCode) (errorCodeEntry, bool) {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+	entry, ok := errorCodes[code]
+	return entry, ok
+}
+
+// CodedError 由错误码注册表构造的业务错误
+type CodedError struct {
+	Code    ErrorCode // 业务错误码
+	Status  int       // HTTP 状态码
+	Message string    // 面向用户的错误描述
+	Err     error     // 可选的底层原因，仅用于日志与 errors.Is/As
+}
+
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode 返回业务错误码
+func (e *CodedError) ErrorCode() ErrorCode {
+	return e.Code
+}
+
+// Wrap 附加底层原因并返回自身
+func (e *CodedError) Wrap(cause error) *CodedError {
+	e.Err = cause
+	return e
+}
+
+// Errorf 按错误码注册表构造业务错误，args 用于填充默认消息中的占位符
+// 未注册的错误码视为 500，消息为 "错误码 <code>"
+//
+// 使用示例:
+//
+//	return nil, abe.Errorf(ErrCodeOrderNotFound, orderID)
+func Errorf(code ErrorCode, args ...any) *CodedError {
+	entry, ok := lookupErrorCode(code)
+	if !ok {
+		return &CodedError{Code: code, Status: http.StatusInternalServerError, Message: fmt.Sprintf("错误码 %d", code)}
+	}
+	msg := entry.message
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return &CodedError{Code: code, Status: entry.status, Message: msg}
+}
+
+// CodedErrorHandler 将实现 Coded 的错误渲染为错误响应的错误处理器
+// 状态码优先使用 CodedError.Status，其次查询错误码注册表，均无时为 500
+//
+// 使用示例:
+//
+//	engine.AddErrorHandler(abe.CodedErrorHandler)
+func CodedErrorHandler(err error) (*ErrorResponse, int) {
+	var coded Coded
+	if !errors.As(err, &coded) {
+		return nil, 0
+	}
+	status := http.StatusInternalServerError
+	var ce *CodedError
+	if errors.As(err, &ce) && ce.Status != 0 {
+		status = ce.Status
+	} else if entry, ok := lookupErrorCode(coded.ErrorCode()); ok {
+		status = entry.status
+	}
+	return &ErrorResponse{Code: coded.ErrorCode(), Msg: coded.Error()}, status
+}
@@ -1,12 +1,46 @@
 package abe
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 )
 
+// 错误响应格式（error.format）
+const (
+	errorFormatAbe     = "abe"     // 默认：{code,msg,data}
+	errorFormatProblem = "problem" // RFC 7807：application/problem+json
+)
+
+// defaultProblemTypeBase 问题类型 URI 的默认前缀，完整类型为 前缀 + 错误码（如 urn:abe:error:403）
+const defaultProblemTypeBase = "urn:abe:error:"
+
+// ProblemDetails RFC 7807 问题详情响应
+type ProblemDetails struct {
+	Type     string    `json:"type"`              // 问题类型 URI，由 error.problem_type_base + 错误码构成
+	Title    string    `json:"title"`             // HTTP 状态码的标准描述
+	Status   int       `json:"status"`            // HTTP 状态码
+	Detail   string    `json:"detail,omitempty"`  // 错误描述（即 abe 格式中的 msg）
+	Instance string    `json:"instance"`          // 请求路径
+	Code     ErrorCode `json:"code"`              // 扩展成员：业务错误码
+	Details  gin.H     `json:"details,omitempty"` // 扩展成员：附加数据（即 abe 格式中的 data）
+}
+
 // errorHandlerMiddleware 统一错误处理中间件
-// 处理 4xx 客户端错误，5xx 错误由 ginRecovery 处理
+// 处理 4xx 客户端错误，5xx 错误由 ginRecovery 处理；已注册的处理器均未命中时由 SentinelErrorHandler 兜底
+// 错误链中含 LocalizedError 时按请求语言翻译响应消息
+// 5xx 错误记录错误日志（含 WithStack 捕获的调用栈），调试模式下在响应 data 中附加 error 与 stack
+// error.format=problem 时以 RFC 7807（application/problem+json）格式输出，默认保持 abe 格式
 func errorHandlerMiddleware(e *Engine) gin.HandlerFunc {
+	problem := strings.EqualFold(strings.TrimSpace(e.config.GetString("error.format")), errorFormatProblem)
+	debugMode := e.config.GetBool("app.debug")
+	typeBase := e.config.GetString("error.problem_type_base")
+	if typeBase == "" {
+		typeBase = defaultProblemTypeBase
+	}
+
 	return func(ctx *gin.Context) {
 		ctx.Next()
 
@@ -16,18 +50,80 @@ func errorHandlerMiddleware(e *Engine) gin.HandlerFunc {
 
 		err := ctx.Errors.Last().Err
 
-		if len(e.errorHandlers) == 0 {
+		// 已注册的处理器优先，框架哨兵错误（限流、超时、URI 过长、请求体过大等）兜底
+		resp, status := e.handleError(err)
+		if resp == nil {
+			panic(err)
+		}
+		resp = localizeErrorResponse(ctx, err, resp)
+		if status >= http.StatusInternalServerError {
+			resp = e.serverErrorResponse(ctx, err, resp, debugMode)
+		}
+		if problem {
+			writeProblem(ctx, typeBase, status, resp)
 			return
 		}
+		ctx.AbortWithStatusJSON(status, *resp)
+	}
+}
 
-		for _, handler := range e.errorHandlers {
-			resp, status := handler(err)
-			if resp != nil {
-				ctx.AbortWithStatusJSON(status, *resp)
-				return
-			}
+// handleError 依次尝试已注册的错误处理器与 SentinelErrorHandler，均未命中时返回 nil
+func (e *Engine) handleError(err error) (*ErrorResponse, int) {
+	for _, handler := range e.errorHandlers {
+		if resp, status := handler(err); resp != nil {
+			return resp, status
 		}
+	}
+	return SentinelErrorHandler(err)
+}
+
+// SentinelErrorHandler 将包装框架哨兵错误（ErrBadRequest、ErrUnauthorized、ErrForbidden、ErrResourceNotFound、
+// ErrTooManyRequests、ErrURITooLong、ErrGatewayTimeout）的错误渲染为对应状态码，响应消息为错误描述；其余错误不处理
+// 错误处理中间件总是在已注册的处理器之后尝试本处理器，无需手动注册
+func SentinelErrorHandler(err error) (*ErrorResponse, int) {
+	status := sentinelStatus(err)
+	if status == http.StatusInternalServerError {
+		return nil, 0
+	}
+	return &ErrorResponse{Code: ErrorCode(status), Msg: err.Error()}, status
+}
 
-		panic(err)
+// serverErrorResponse 记录 5xx 错误；调试模式下返回附加了错误详情与调用栈的响应副本
+func (e *Engine) serverErrorResponse(ctx *gin.Context, err error, resp *ErrorResponse, debugMode bool) *ErrorResponse {
+	stack := ErrorStack(err)
+	if e.logger != nil {
+		attrs := []any{"error", err, "method", ctx.Request.Method, "path", ctx.Request.URL.Path}
+		if stack != nil {
+			attrs = append(attrs, "stack", string(stack))
+		}
+		requestLogger(ctx, e.logger).Error("请求处理发生服务端错误", attrs...)
 	}
+	if !debugMode {
+		return resp
+	}
+	data := gin.H{}
+	for k, v := range resp.Data {
+		data[k] = v
+	}
+	data["error"] = err.Error()
+	if stack != nil {
+		data["stack"] = stackLines(stack)
+	}
+	debugResp := *resp
+	debugResp.Data = data
+	return &debugResp
+}
+
+// writeProblem 将错误响应转换为 RFC 7807 问题详情输出
+func writeProblem(ctx *gin.Context, typeBase string, status int, resp *ErrorResponse) {
+	ctx.Header("Content-Type", "application/problem+json")
+	ctx.AbortWithStatusJSON(status, ProblemDetails{
+		Type:     fmt.Sprintf("%s%d", typeBase, resp.Code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   resp.Msg,
+		Instance: ctx.Request.URL.Path,
+		Code:     resp.Code,
+		Details:  resp.Data,
+	})
 }
@@ -0,0 +1,118 @@
+package abe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// EventContentTypeMetadataKey 事件载荷编码类型在消息元数据中的键名
+const EventContentTypeMetadataKey = "abe_content_type"
+
+// Codec 事件载荷编解码器
+type Codec[T any] interface {
+	// Marshal 将事件编码为载荷
+	Marshal(event T) ([]byte, error)
+	// Unmarshal 将载荷解码为事件
+	Unmarshal(data []byte) (T, error)
+	// ContentType 返回编码类型，写入消息元数据 abe_content_type
+	ContentType() string
+}
+
+// JSONCodec JSON 编解码器（默认）
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Marshal(event T) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+func (JSONCodec[T]) Unmarshal(data []byte) (T, error) {
+	var event T
+	err := json.Unmarshal(data, &event)
+	return event, err
+}
+
+func (JSONCodec[T]) ContentType() string {
+	return "application/json"
+}
+
+// MsgPackCodec MessagePack 编解码器，载荷体积通常明显小于 JSON，适合高频内部事件
+// 字段名默认沿用 msgpack 标签，未设置时使用字段名
+type MsgPackCodec[T any] struct{}
+
+func (MsgPackCodec[T]) Marshal(event T) ([]byte, error) {
+	return msgpack.Marshal(event)
+}
+
+func (MsgPackCodec[T]) Unmarshal(data []byte) (T, error) {
+	var event T
+	err := msgpack.Unmarshal(data, &event)
+	return event, err
+}
+
+func (MsgPackCodec[T]) ContentType() string {
+	return "application/msgpack"
+}
+
+// PublishEvent 以 JSON 编码发布事件
+//
+// 使用示例:
+//
+//	err := abe.PublishEvent(e.EventBus(), "user.created", UserCreated{ID: id})
+func PublishEvent[T any](bus EventBus, topic string, event T) error {
+	return PublishEventWith[T](bus, topic, event, JSONCodec[T]{})
+}
+
+// PublishEventWith 使用指定编解码器发布事件
+//
+// 使用示例:
+//
+//	err := abe.PublishEventWith(e.EventBus(), "metrics.sample", sample, abe.MsgPackCodec[Sample]{})
+func PublishEventWith[T any](bus EventBus, topic string, event T, codec Codec[T]) error {
+	return publishEvent(context.Background(), bus, topic, event, codec)
+}
+
+// PublishEventContext 以 JSON 编码发布事件，并将 ctx 中的链路上下文注入消息元数据，订阅方处理时延续该链路
+//
+// 使用示例:
+//
+//	err := abe.PublishEventContext(ctx.Request.Context(), e.EventBus(), "user.created", UserCreated{ID: id})
+func PublishEventContext[T any](ctx context.Context, bus EventBus, topic string, event T) error {
+	return publishEvent(ctx, bus, topic, event, JSONCodec[T]{})
+}
+
+// publishEvent 编码并发布事件
+func publishEvent[T any](ctx context.Context, bus EventBus, topic string, event T, codec Codec[T]) error {
+	payload, err := codec.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("编码事件失败：%w", err)
+	}
+	msg := NewMessage(payload).WithTraceContext(ctx)
+	msg.SetMetadata(EventContentTypeMetadataKey, codec.ContentType())
+	return bus.Publish(topic, msg)
+}
+
+// SubscribeEvent 订阅以 JSON 编码的事件，处理语义同 SubscribeFunc
+func SubscribeEvent[T any](ctx context.Context, e *Engine, topic string, handler func(ctx context.Context, event T) error, opts ...SubscribeOption) error {
+	return SubscribeEventWith[T](ctx, e, topic, JSONCodec[T]{}, handler, opts...)
+}
+
+// SubscribeEventWith 使用指定编解码器订阅事件，处理语义同 SubscribeFunc
+// 载荷解码失败视为处理失败，按订阅选项重试或转发死信
+//
+// 使用示例:
+//
+//	err := abe.SubscribeEventWith(ctx, e, "metrics.sample", abe.MsgPackCodec[Sample]{},
+//	    func(ctx context.Context, s Sample) error { return store(s) },
+//	    abe.WithRetry(3, 100*time.Millisecond))
+func SubscribeEventWith[T any](ctx context.Context, e *Engine, topic string, codec Codec[T], handler func(ctx context.Context, event T) error, opts ...SubscribeOption) error {
+	return e.SubscribeFunc(ctx, topic, func(ctx context.Context, msg *EventMessage) error {
+		event, err := codec.Unmarshal(msg.Payload())
+		if err != nil {
+			return fmt.Errorf("解码事件失败（%s）：%w", codec.ContentType(), err)
+		}
+		return handler(ctx, event)
+	}, opts...)
+}
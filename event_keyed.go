@@ -0,0 +1,176 @@
+package abe
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// EventKeyMetadataKey 聚合键在消息元数据中的键名
+const EventKeyMetadataKey = "abe_event_key"
+
+// Key 返回消息的聚合键（如实体 ID），未设置时返回空字符串。
+func (m *EventMessage) Key() string {
+	return m.msg.Metadata.Get(EventKeyMetadataKey)
+}
+
+// WithKey 设置消息的聚合键并返回自身，便于链式调用。
+// 相同聚合键的消息在 SubscribeKeyed 中按发布顺序依次处理。
+func (m *EventMessage) WithKey(key string) *EventMessage {
+	if key != "" {
+		m.msg.Metadata.Set(EventKeyMetadataKey, key)
+	}
+	return m
+}
+
+// PublishEventKeyed 以指定聚合键发布消息
+//
+// 参数:
+//   - bus: 事件总线
+//   - topic: 主题
+//   - key: 聚合键（如订单 ID），为空时等同于普通发布
+//   - msgs: 待发布的消息
+//
+// 使用示例:
+//
+//	err := abe.PublishEventKeyed(e.EventBus(), "order.events", order.ID, abe.NewMessage(payload))
+func PublishEventKeyed(bus EventBus, topic, key string, msgs ...*EventMessage) error {
+	for _, m := range msgs {
+		m.WithKey(key)
+	}
+	return bus.Publish(topic, msgs...)
+}
+
+// KeyedHandler 按聚合键顺序处理的消息处理函数
+type KeyedHandler func(ctx context.Context, msg *EventMessage) error
+
+// KeyedSubscribeOptions SubscribeKeyed 选项
+type KeyedSubscribeOptions struct {
+	Concurrency int               // 同时处理的聚合键数量上限，默认 runtime.NumCPU()
+	QueueSize   int               // 单个聚合键的待处理队列长度，队列满时对总线形成背压，默认 64
+	Options     []SubscribeOption // 单条消息的重试与死信选项（WithRetry、WithDeadLetter），WithConcurrency 不生效
+}
+
+// SubscribeKeyed 订阅主题并按聚合键保证顺序处理
+//
+// 参数:
+//   - ctx: 订阅生命周期上下文，取消后停止接收，已入队的消息仍会处理完毕
+//   - topic: 主题
+//   - handler: 消息处理函数
+//   - opts: 并发与队列选项
+//
+// 返回:
+//   - error: 订阅失败时返回错误；订阅成功后在后台处理消息
+//
+// 行为:
+//   - 相同聚合键的消息按到达顺序串行处理；进程内总线需开启 event.block_publish_until_ack，
+//     到达顺序才与发布顺序一致，不同聚合键之间并行处理（受 Concurrency 限制）
+//   - 未设置聚合键的消息不保证顺序，各自独立处理
+//   - 每个活跃聚合键对应一个队列，队列排空后立即回收，不会随键的数量无限增长
+//   - 消息在处理函数返回后才确认：成功时确认（Ack），失败时按 Options 中的 WithRetry、WithDeadLetter 重试或转入死信，
+//     未设置时负确认（Nack）由总线重投，与 SubscribeFunc 一致；重投的消息会排在同键已到达的消息之后，
+//     需要保持失败消息的顺序时应使用 WithRetry 在队列内重试
+//   - 总线在上一条消息确认前不投递下一条时（Kafka 同一分区、Redis Stream 同一订阅、gochannel 开启
+//     event.block_publish_until_ack），不同聚合键之间也无法并行
+func (e *Engine) SubscribeKeyed(ctx context.Context, topic string, handler KeyedHandler, opts KeyedSubscribeOptions) error {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = runtime.NumCPU()
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 64
+	}
+	o, err := newSubscribeOptions(topic, opts.Options)
+	if err != nil {
+		return err
+	}
+
+	ch, err := e.EventBus().Subscribe(ctx, topic)
+	if err != nil {
+		return fmt.Errorf("订阅主题 %s 失败：%w", topic, err)
+	}
+
+	d := &keyedDispatcher{
+		engine:  e,
+		topic:   topic,
+		handler: handler,
+		options: o,
+		sem:     make(chan struct{}, opts.Concurrency),
+		size:    opts.QueueSize,
+		queues:  make(map[string]*keyedQueue),
+	}
+	go d.run(ctx, ch)
+	return nil
+}
+
+// keyedDispatcher 将消息分发到按聚合键划分的队列
+type keyedDispatcher struct {
+	engine  *Engine
+	topic   string
+	handler KeyedHandler
+	options subscribeOptions
+	sem     chan struct{}
+	size    int
+
+	mu     sync.Mutex
+	queues map[string]*keyedQueue // 聚合键 -> 待处理队列（仅包含活跃键）
+}
+
+// keyedQueue 单个聚合键的待处理队列
+type keyedQueue struct {
+	ch      chan *EventMessage
+	pending int // 已分配到该队列但尚未处理完的消息数（含正在入队的消息），由 keyedDispatcher.mu 保护
+}
+
+func (d *keyedDispatcher) run(ctx context.Context, ch <-chan *EventMessage) {
+	for msg := range ch {
+		key := msg.Key()
+		if key == "" {
+			// 无聚合键：以消息 UUID 作为独立队列，不与其他消息排序
+			key = "uuid:" + msg.UUID()
+		}
+		d.enqueue(ctx, key, msg)
+	}
+}
+
+// enqueue 将消息放入聚合键队列，必要时创建队列并启动其处理协程
+// 持锁登记待处理计数后释放锁再入队，队列已满时只阻塞分发协程，不影响其他键的处理与回收；
+// 处理协程在计数归零时才回收队列，保证不会向已回收的队列投递消息
+func (d *keyedDispatcher) enqueue(ctx context.Context, key string, msg *EventMessage) {
+	d.mu.Lock()
+	q, ok := d.queues[key]
+	if !ok {
+		q = &keyedQueue{ch: make(chan *EventMessage, d.size)}
+		d.queues[key] = q
+		go d.drain(ctx, key, q)
+	}
+	q.pending++
+	d.mu.Unlock()
+
+	q.ch <- msg
+}
+
+// drain 依次处理单个聚合键队列中的消息，待处理计数归零后回收
+func (d *keyedDispatcher) drain(ctx context.Context, key string, q *keyedQueue) {
+	for {
+		d.process(ctx, <-q.ch)
+
+		d.mu.Lock()
+		q.pending--
+		if q.pending == 0 {
+			delete(d.queues, key)
+			d.mu.Unlock()
+			return
+		}
+		d.mu.Unlock()
+	}
+}
+
+// process 在并发配额内执行处理函数，按订阅选项重试、转入死信并完成确认
+// 订阅取消后已入队的消息仍会处理完毕
+func (d *keyedDispatcher) process(ctx context.Context, msg *EventMessage) {
+	d.sem <- struct{}{}
+	defer func() { <-d.sem }()
+
+	d.engine.handleWithRetry(context.WithoutCancel(ctx), d.topic, msg, EventHandler(d.handler), d.options)
+}
@@ -0,0 +1,140 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newKeyedTestDispatcher(e *Engine, handler KeyedHandler, size int) *keyedDispatcher {
+	return &keyedDispatcher{
+		engine:  e,
+		topic:   "order.events",
+		handler: handler,
+		options: subscribeOptions{maxAttempts: 1, concurrency: 1},
+		sem:     make(chan struct{}, 4),
+		size:    size,
+		queues:  make(map[string]*keyedQueue),
+	}
+}
+
+func TestSubscribeKeyedRetriesAndCapturesDeadLetter(t *testing.T) {
+	e := newEventTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerErr := errors.New("库存不足")
+	err := e.SubscribeKeyed(ctx, "stock.reserve", func(context.Context, *EventMessage) error {
+		return handlerErr
+	}, KeyedSubscribeOptions{Options: []SubscribeOption{WithRetry(2, time.Millisecond)}})
+	if err != nil {
+		t.Fatalf("订阅失败：%v", err)
+	}
+
+	if err := PublishEventKeyed(e.EventBus(), "stock.reserve", "sku-1", NewMessage([]byte(`{}`))); err != nil {
+		t.Fatalf("发布失败：%v", err)
+	}
+	if dl := waitDeadLetters(t, e, "stock.reserve", 1)[0]; dl.Attempts != 2 {
+		t.Fatalf("重试耗尽后应写入死信存储，尝试次数 = %d，期望 2", dl.Attempts)
+	}
+}
+
+func TestKeyedDispatcherAcksAfterHandling(t *testing.T) {
+	e := newEventTestEngine(t)
+	release := make(chan struct{})
+	d := newKeyedTestDispatcher(e, func(_ context.Context, msg *EventMessage) error {
+		<-release
+		if msg.Key() == "bad" {
+			return errors.New("库存不足")
+		}
+		return nil
+	}, 4)
+	ctx := context.Background()
+
+	ok := NewMessage(nil).WithKey("good")
+	bad := NewMessage(nil).WithKey("bad")
+	d.enqueue(ctx, "good", ok)
+	d.enqueue(ctx, "bad", bad)
+
+	select {
+	case <-ok.Acked():
+		t.Fatal("处理函数返回前不应确认消息")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+
+	select {
+	case <-ok.Acked():
+	case <-time.After(time.Second):
+		t.Fatal("处理成功的消息未确认")
+	}
+	select {
+	case <-bad.Nacked():
+	case <-time.After(time.Second):
+		t.Fatal("处理失败的消息未负确认")
+	}
+}
+
+func TestKeyedDispatcherFullQueueDoesNotBlockOtherKeys(t *testing.T) {
+	e := newEventTestEngine(t)
+	release := make(chan struct{})
+	processed := make(chan string, 8)
+	d := newKeyedTestDispatcher(e, func(_ context.Context, msg *EventMessage) error {
+		if msg.Key() == "slow" {
+			<-release
+		}
+		processed <- msg.Key()
+		return nil
+	}, 1)
+	ctx := context.Background()
+
+	// slow 键：首条消息阻塞处理，第二条填满队列，第三条使入队阻塞
+	for range 3 {
+		go d.enqueue(ctx, "slow", NewMessage(nil).WithKey("slow"))
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		d.enqueue(ctx, "fast", NewMessage(nil).WithKey("fast"))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("其他键的入队被已满的队列阻塞")
+	}
+	if key := <-processed; key != "fast" {
+		t.Fatalf("首个处理完成的消息键 = %q，期望 fast", key)
+	}
+
+	close(release)
+	for range 3 {
+		select {
+		case <-processed:
+		case <-time.After(time.Second):
+			t.Fatal("释放后 slow 键的消息未处理完毕")
+		}
+	}
+}
+
+func TestSubscribeKeyedPublishSyncReportsHandlerError(t *testing.T) {
+	e := newEventTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerErr := errors.New("库存不足")
+	err := e.SubscribeKeyed(ctx, "stock.reserve", func(context.Context, *EventMessage) error {
+		return handlerErr
+	}, KeyedSubscribeOptions{})
+	if err != nil {
+		t.Fatalf("订阅失败：%v", err)
+	}
+
+	// 消息在处理完成后才确认，同步发布可得到处理函数的错误
+	err = PublishEventSync(ctx, e.EventBus(), "stock.reserve", map[string]int{"sku": 1}, 2*time.Second)
+	if !errors.Is(err, handlerErr) {
+		t.Fatalf("同步发布应返回处理错误，实际 %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// 事件处理相关的元数据键名
+const (
+	EventAttemptMetadataKey       = "abe_attempt"              // 当前处理尝试次数（从 1 开始）
+	DeadLetterTopicMetadataKey    = "abe_dead_letter_topic"    // 死信消息的原始主题
+	DeadLetterErrorMetadataKey    = "abe_dead_letter_error"    // 死信消息最后一次处理失败的错误
+	DeadLetterAttemptsMetadataKey = "abe_dead_letter_attempts" // 死信消息的已尝试次数
+)
+
+// maxRetryBackoff 重试等待时间上限，指数退避翻倍后超过该值时按该值等待
+const maxRetryBackoff = 5 * time.Minute
+
+// EventHandler 事件消息处理函数
+type EventHandler func(ctx context.Context, msg *EventMessage) error
+
+// SubscribeOption SubscribeFunc 选项
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	maxAttempts     int
+	backoff         time.Duration
+	deadLetterTopic string
+	concurrency     int
+}
+
+// WithRetry 处理失败时按指数退避重试
+//
+// 参数:
+//   - maxAttempts: 最大尝试次数（含首次），为 1 表示不重试，小于 1 时按 1 处理
+//   - backoff: 首次重试前的等待时间，之后每次翻倍，最长不超过 5 分钟
+//
+// 重试耗尽后记录错误日志、写入死信存储（见 Engine.DeadLetters）并确认（Ack）消息，避免毒消息被无限重投。
+func WithRetry(maxAttempts int, backoff time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.maxAttempts = maxAttempts
+		o.backoff = backoff
+	}
+}
+
+// WithDeadLetter 处理最终失败（重试耗尽）后，将原始消息转发到死信主题
+//
+// 参数:
+//   - topic: 死信主题，不能与订阅主题相同
+//
+// 转发的消息保留原始载荷与元数据，并附加 abe_dead_letter_topic、abe_dead_letter_error、
+// abe_dead_letter_attempts 元数据；订阅死信主题即可检查或手动重放。消息同时写入死信存储，
+// 可通过 DeadLetterListHandler、DeadLetterReplayHandler 查询与重放。
+// 转发在独立协程中进行，失败仅记录日志，不阻塞当前订阅的后续消息。
+func WithDeadLetter(topic string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.deadLetterTopic = topic
+	}
+}
+
+// WithConcurrency 设置并发处理消息的工作协程数（默认 1，按到达顺序逐条处理）
+// 大于 1 时不保证处理顺序；运行期可通过 Subscription.SetConcurrency 调整。
+// 实际并行度还取决于总线：gochannel 开启 event.block_publish_until_ack 或 Kafka 同一分区或
+// Redis Stream 同一订阅时，总线在上一条消息确认前不会投递下一条
+func WithConcurrency(n int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.concurrency = n
+	}
+}
+
+// SubscribeFunc 订阅主题并以处理函数逐条处理消息（默认单协程，按到达顺序）
+//
+// 参数:
+//   - ctx: 订阅生命周期上下文，取消后停止接收
+//   - topic: 主题
+//   - handler: 消息处理函数，返回 nil 时确认消息
+//   - opts: 订阅选项，如 WithRetry、WithDeadLetter
+//
+// 返回:
+//   - error: 订阅失败或死信主题与订阅主题相同时返回错误；订阅成功后在后台处理消息
+//
+// 行为:
+//   - 未设置 WithRetry 时仅尝试一次，失败则负确认（Nack），由总线决定是否重投
+//   - 设置 WithRetry 时失败按指数退避重试，当前尝试次数写入元数据 abe_attempt；
+//     重试耗尽后记录错误、写入死信存储并确认消息
+//   - 设置 WithDeadLetter 时，最终失败的消息写入死信存储并转发到死信主题后确认
+//   - 处理函数 panic 视为处理失败
+//
+// 使用示例:
+//
+//	err := e.SubscribeFunc(ctx, "order.created", handleOrderCreated, abe.WithRetry(5, 200*time.Millisecond))
+func (e *Engine) SubscribeFunc(ctx context.Context, topic string, handler EventHandler, opts ...SubscribeOption) error {
+	_, err := e.Subscribe(ctx, topic, handler, opts...)
+	return err
+}
+
+// Subscribe 与 SubscribeFunc 相同，额外返回订阅句柄，用于在运行期调整工作协程数
+//
+// 使用示例:
+//
+//	sub, err := e.Subscribe(ctx, "report.render", render, abe.WithConcurrency(2))
+//	// 积压增加时扩容
+//	_ = sub.SetConcurrency(8)
+func (e *Engine) Subscribe(ctx context.Context, topic string, handler EventHandler, opts ...SubscribeOption) (*Subscription, error) {
+	o, err := newSubscribeOptions(topic, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := e.EventBus().Subscribe(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("订阅主题 %s 失败：%w", topic, err)
+	}
+
+	sub := &Subscription{
+		topic: topic,
+		ctx:   ctx,
+		ch:    ch,
+		handle: func(msg *EventMessage) {
+			e.handleWithRetry(ctx, topic, msg, handler, o)
+		},
+	}
+	_ = sub.SetConcurrency(o.concurrency)
+	return sub, nil
+}
+
+// newSubscribeOptions 应用订阅选项并校验，未设置的选项取默认值（仅尝试一次、单协程）
+func newSubscribeOptions(topic string, opts []SubscribeOption) (subscribeOptions, error) {
+	o := subscribeOptions{maxAttempts: 1, concurrency: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxAttempts < 1 {
+		o.maxAttempts = 1
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+	if o.deadLetterTopic != "" && o.deadLetterTopic == topic {
+		return o, fmt.Errorf("死信主题不能与订阅主题相同：%s", topic)
+	}
+	return o, nil
+}
+
+// Subscription 订阅句柄，支持在运行期增减工作协程而不中断订阅
+type Subscription struct {
+	topic  string
+	ctx    context.Context
+	ch     <-chan *EventMessage
+	handle func(msg *EventMessage)
+
+	mu      sync.Mutex
+	workers []chan struct{} // 每个工作协程的停止信号
+}
+
+// Topic 订阅的主题
+func (s *Subscription) Topic() string {
+	return s.topic
+}
+
+// Concurrency 当前工作协程数
+func (s *Subscription) Concurrency() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.workers)
+}
+
+// SetConcurrency 调整工作协程数（n >= 1）
+// 扩容立即启动新协程；缩容时被停止的协程处理完手头消息后退出，不会丢弃已取出的消息
+func (s *Subscription) SetConcurrency(n int) error {
+	if n < 1 {
+		return errors.New("订阅并发数必须大于 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.workers) < n {
+		stop := make(chan struct{})
+		s.workers = append(s.workers, stop)
+		go s.work(stop)
+	}
+	for len(s.workers) > n {
+		last := len(s.workers) - 1
+		close(s.workers[last])
+		s.workers = s.workers[:last]
+	}
+	return nil
+}
+
+// work 工作协程：每处理完一条消息先检查停止信号，保证缩容时不中断处理中的消息
+func (s *Subscription) work(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		select {
+		case <-stop:
+			return
+		case <-s.ctx.Done():
+			return
+		case msg, ok := <-s.ch:
+			if !ok {
+				return
+			}
+			s.handle(msg)
+		}
+	}
+}
+
+// handleWithRetry 按选项处理单条消息并完成确认
+func (e *Engine) handleWithRetry(ctx context.Context, topic string, msg *EventMessage, handler EventHandler, o subscribeOptions) {
+	ctx, span := startEventSpan(ctx, topic, msg)
+	defer span.End()
+
+	var err error
+	for attempt := 1; attempt <= o.maxAttempts; attempt++ {
+		if attempt > 1 {
+			delay := retryDelay(o.backoff, attempt-1)
+			e.logger.Warn("事件消息处理失败，等待重试", "topic", topic, "message_uuid", msg.UUID(), "attempt", attempt-1, "max_attempts", o.maxAttempts, "delay", delay, "error", err)
+			select {
+			case <-ctx.Done():
+				// 订阅已取消：交还消息，由总线决定是否重投
+				msg.Nack()
+				return
+			case <-time.After(delay):
+			}
+		}
+
+		msg.SetMetadata(EventAttemptMetadataKey, strconv.Itoa(attempt))
+		if err = callEventHandler(ctx, handler, msg); err == nil {
+			msg.Ack()
+			return
+		}
+	}
+
+	msg.fail(err)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	if o.deadLetterTopic != "" {
+		e.logger.Error("事件消息处理失败，转发到死信主题", "topic", topic, "message_uuid", msg.UUID(), "attempts", o.maxAttempts, "dead_letter_topic", o.deadLetterTopic, "error", err)
+		e.forwardDeadLetter(topic, o.deadLetterTopic, msg, err, o.maxAttempts)
+		msg.Ack()
+		return
+	}
+	if o.maxAttempts == 1 {
+		e.logger.Error("事件消息处理失败", "topic", topic, "message_uuid", msg.UUID(), "error", err)
+		msg.Nack()
+		return
+	}
+	e.logger.Error("事件消息重试耗尽，放弃处理", "topic", topic, "message_uuid", msg.UUID(), "attempts", o.maxAttempts, "error", err)
+	if cerr := e.CaptureDeadLetter(topic, msg, err, o.maxAttempts); cerr != nil {
+		e.logger.Error("写入死信存储失败", "topic", topic, "message_uuid", msg.UUID(), "error", cerr)
+	}
+	msg.Ack()
+}
+
+// retryDelay 计算第 retry 次重试（从 1 开始）前的等待时间：backoff 逐次翻倍，不超过 maxRetryBackoff
+func retryDelay(backoff time.Duration, retry int) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	delay := min(backoff, maxRetryBackoff)
+	for i := 1; i < retry && delay < maxRetryBackoff; i++ {
+		delay = min(delay<<1, maxRetryBackoff)
+	}
+	return delay
+}
+
+// forwardDeadLetter 将失败消息写入死信存储，并在独立协程中转发到死信主题
+// 复制载荷与元数据生成新消息，避免与原消息的确认状态相互影响
+func (e *Engine) forwardDeadLetter(topic, dlTopic string, msg *EventMessage, cause error, attempts int) {
+	if err := e.CaptureDeadLetter(topic, msg, cause, attempts); err != nil {
+		e.logger.Error("写入死信存储失败", "topic", topic, "message_uuid", msg.UUID(), "error", err)
+	}
+
+	dl := NewMessage(append([]byte(nil), msg.Payload()...))
+	for k, v := range msg.msg.Metadata {
+		dl.SetMetadata(k, v)
+	}
+	dl.SetMetadata(DeadLetterTopicMetadataKey, topic)
+	dl.SetMetadata(DeadLetterErrorMetadataKey, cause.Error())
+	dl.SetMetadata(DeadLetterAttemptsMetadataKey, strconv.Itoa(attempts))
+
+	go func() {
+		if err := e.EventBus().Publish(dlTopic, dl); err != nil {
+			e.logger.Error("转发死信消息失败", "topic", topic, "dead_letter_topic", dlTopic, "message_uuid", msg.UUID(), "error", err)
+		}
+	}()
+}
+
+// callEventHandler 调用处理函数，将 panic 转换为错误
+func callEventHandler(ctx context.Context, handler EventHandler, msg *EventMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("处理函数发生 panic：%v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
@@ -0,0 +1,41 @@
+package abe
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelayCapped(t *testing.T) {
+	cases := []struct {
+		name    string
+		backoff time.Duration
+		retry   int
+		want    time.Duration
+	}{
+		{"首次重试", 100 * time.Millisecond, 1, 100 * time.Millisecond},
+		{"逐次翻倍", 100 * time.Millisecond, 4, 800 * time.Millisecond},
+		{"超过上限", time.Minute, 4, maxRetryBackoff},
+		{"移位溢出", time.Second, 100, maxRetryBackoff},
+		{"初始值超过上限", time.Hour, 1, maxRetryBackoff},
+		{"未设置退避", 0, 3, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := retryDelay(tc.backoff, tc.retry); got != tc.want {
+				t.Fatalf("期望 %v，实际 %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewSubscribeOptionsClampsNonPositiveValues(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		o, err := newSubscribeOptions("orders", []SubscribeOption{WithRetry(n, time.Millisecond), WithConcurrency(n)})
+		if err != nil {
+			t.Fatalf("n=%d 时不应返回错误：%v", n, err)
+		}
+		if o.maxAttempts != 1 || o.concurrency != 1 {
+			t.Errorf("n=%d 时应按 1 处理，实际 maxAttempts=%d concurrency=%d", n, o.maxAttempts, o.concurrency)
+		}
+	}
+}
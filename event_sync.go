@@ -0,0 +1,116 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSyncUnsupported 事件总线实现不支持同步发布（如跨进程的 Kafka 总线）
+	ErrSyncUnsupported = errors.New("event bus does not support synchronous publish")
+	// ErrEventNacked 消息被订阅者负确认且未提供具体错误
+	ErrEventNacked = errors.New("event nacked by subscriber")
+)
+
+// eventSyncMetadataKey 标记需要同步回执的消息
+const eventSyncMetadataKey = "abe_sync"
+
+// syncPublisher 支持同步发布的事件总线实现
+type syncPublisher interface {
+	publishSync(ctx context.Context, topic string, msg *EventMessage) error
+}
+
+// PublishEventSync 发布事件并等待至少一个订阅者处理完成
+//
+// 参数:
+//   - ctx: 上下文，取消时立即返回
+//   - bus: 事件总线
+//   - topic: 主题
+//   - event: 事件对象，以 JSON 编码
+//   - timeout: 等待超时时间，<= 0 表示仅受 ctx 控制
+//
+// 返回:
+//   - error: 首个订阅者确认（Ack）时返回其处理错误（成功为 nil）；负确认（Nack）时返回处理错误或 ErrEventNacked；
+//     超时返回 context.DeadlineExceeded；总线不支持同步发布时返回 ErrSyncUnsupported
+//
+// 说明:
+//   - 仅进程内总线（gochannel）支持同步发布，跨进程总线无法获知远端处理结果
+//   - 处理错误通过 SubscribeFunc、SubscribeKeyed 的处理函数返回值传递；直接使用 Subscribe 通道时负确认只能得到 ErrEventNacked
+func PublishEventSync[T any](ctx context.Context, bus EventBus, topic string, event T, timeout time.Duration) error {
+	sp, ok := bus.(syncPublisher)
+	if !ok {
+		return ErrSyncUnsupported
+	}
+	codec := JSONCodec[T]{}
+	payload, err := codec.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("编码事件失败：%w", err)
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	msg := NewMessage(payload)
+	msg.SetMetadata(EventContentTypeMetadataKey, codec.ContentType())
+	return sp.publishSync(ctx, topic, msg)
+}
+
+// syncReplies 同步发布的回执登记表：消息 UUID -> 回执通道
+type syncReplies struct {
+	mu      sync.Mutex
+	waiters map[string]chan error
+}
+
+// register 登记回执通道
+func (r *syncReplies) register(id string) chan error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.waiters == nil {
+		r.waiters = make(map[string]chan error)
+	}
+	ch := make(chan error, 1)
+	r.waiters[id] = ch
+	return ch
+}
+
+// remove 移除回执通道
+func (r *syncReplies) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.waiters, id)
+}
+
+// reply 投递回执；仅首个回执生效，之后的回执（其他订阅者或重投）被忽略
+func (r *syncReplies) reply(id string, err error) {
+	r.mu.Lock()
+	ch, ok := r.waiters[id]
+	delete(r.waiters, id)
+	r.mu.Unlock()
+	if ok {
+		ch <- err
+	}
+}
+
+// watch 监听订阅者收到的消息副本的确认结果并回执
+func (r *syncReplies) watch(m *EventMessage) {
+	id := m.Metadata(eventSyncMetadataKey)
+	if id == "" {
+		return
+	}
+	go func() {
+		select {
+		case <-m.Acked():
+			r.reply(id, m.err)
+		case <-m.Nacked():
+			if m.err != nil {
+				r.reply(id, m.err)
+			} else {
+				r.reply(id, ErrEventNacked)
+			}
+		}
+	}()
+}
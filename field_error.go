@@ -0,0 +1,92 @@
+package abe
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldViolation 单个字段的错误信息
+type FieldViolation struct {
+	Field   string `json:"field"`         // 字段名
+	Message string `json:"message"`       // 面向用户的错误描述
+	Tag     string `json:"tag,omitempty"` // 校验规则标签（业务错误为空）
+}
+
+// FieldErrors 字段级错误集合，包装 ErrBadRequest
+// 绑定校验失败与业务规则校验失败（如"邮箱已注册"）统一使用该类型，客户端得到一致的错误结构
+type FieldErrors []FieldViolation
+
+func (fe FieldErrors) Error() string {
+	msgs := make([]string, 0, len(fe))
+	for _, v := range fe {
+		msgs = append(msgs, v.Field+": "+v.Message)
+	}
+	return "参数校验失败: " + strings.Join(msgs, "; ")
+}
+
+func (fe FieldErrors) Unwrap() error {
+	return ErrBadRequest
+}
+
+// FieldError 构造单个字段的业务错误
+//
+// 使用示例:
+//
+//	if exists {
+//	    return nil, abe.FieldError("email", "该邮箱已注册")
+//	}
+func FieldError(field, message string) FieldErrors {
+	return FieldErrors{{Field: field, Message: message}}
+}
+
+// Add 追加字段错误并返回新的集合，便于一次返回多个字段的错误
+func (fe FieldErrors) Add(field, message string) FieldErrors {
+	return append(fe, FieldViolation{Field: field, Message: message})
+}
+
+// BindingFieldErrors 将绑定校验错误（validator.ValidationErrors）转换为 FieldErrors
+// 错误描述使用请求上下文中的翻译器；非校验错误原样返回
+//
+// 使用示例:
+//
+//	if err := ctx.ShouldBindJSON(&req); err != nil {
+//	    return nil, abe.BindingFieldErrors(ctx, err)
+//	}
+func BindingFieldErrors(ctx *gin.Context, err error) error {
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return err
+	}
+	trans := Translator(ctx)
+	fe := make(FieldErrors, 0, len(ves))
+	for _, ve := range ves {
+		msg := ve.Error()
+		if trans != nil {
+			msg = ve.Translate(trans)
+		}
+		fe = append(fe, FieldViolation{Field: ve.Field(), Message: msg, Tag: ve.Tag()})
+	}
+	return fe
+}
+
+// FieldErrorsHandler 将 FieldErrors 渲染为 400 响应的错误处理器
+// 响应体形如 {"code":400,"msg":"参数校验失败","data":{"errors":[{"field":"email","message":"..."}]}}
+//
+// 使用示例:
+//
+//	engine.AddErrorHandler(abe.FieldErrorsHandler)
+func FieldErrorsHandler(err error) (*ErrorResponse, int) {
+	var fe FieldErrors
+	if !errors.As(err, &fe) {
+		return nil, 0
+	}
+	return &ErrorResponse{
+		Code: ErrorCode(http.StatusBadRequest),
+		Msg:  "参数校验失败",
+		Data: gin.H{"errors": fe},
+	}, http.StatusBadRequest
+}
@@ -0,0 +1,115 @@
+package abe
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// funcRoute FuncController 中登记的路由
+type funcRoute struct {
+	method   string
+	path     string
+	handlers []gin.HandlerFunc
+}
+
+// FuncController 由处理函数直接构建的控制器，适用于无需结构体与依赖注入的简单接口
+// 实现 Controller 接口，通过 Provider 包装后即可 AddController；路由在挂载阶段按登记顺序注册
+//
+// 使用示例:
+//
+//	engine.AddController(abe.NewController().
+//	    Prefix("/users").
+//	    UseGroup("auth").
+//	    GET("/:id", getUser).
+//	    POST("", createUser).
+//	    Provider())
+type FuncController struct {
+	prefix      string
+	groupNames  []string
+	sharedNames []string
+	middlewares []gin.HandlerFunc
+	routes      []funcRoute
+}
+
+// NewController 创建函数式控制器
+func NewController() *FuncController {
+	return &FuncController{}
+}
+
+// Prefix 设置控制器路由前缀（相对于引擎 basePath）
+func (c *FuncController) Prefix(prefix string) *FuncController {
+	c.prefix = prefix
+	return c
+}
+
+// Use 追加作用于本控制器全部路由的中间件
+func (c *FuncController) Use(handlers ...gin.HandlerFunc) *FuncController {
+	c.middlewares = append(c.middlewares, handlers...)
+	return c
+}
+
+// UseGroup 追加中间件分组（MiddlewareManager.CreateGroup 创建），挂载时解析，分组不存在时 panic
+func (c *FuncController) UseGroup(names ...string) *FuncController {
+	c.groupNames = append(c.groupNames, names...)
+	return c
+}
+
+// UseShared 追加共享中间件（MiddlewareManager.RegisterShared 注册），挂载时解析，不存在时 panic
+func (c *FuncController) UseShared(names ...string) *FuncController {
+	c.sharedNames = append(c.sharedNames, names...)
+	return c
+}
+
+// Handle 登记任意方法的路由
+func (c *FuncController) Handle(method, relPath string, handlers ...gin.HandlerFunc) *FuncController {
+	c.routes = append(c.routes, funcRoute{method: method, path: relPath, handlers: handlers})
+	return c
+}
+
+// GET 登记 GET 路由
+func (c *FuncController) GET(relPath string, handlers ...gin.HandlerFunc) *FuncController {
+	return c.Handle(http.MethodGet, relPath, handlers...)
+}
+
+// POST 登记 POST 路由
+func (c *FuncController) POST(relPath string, handlers ...gin.HandlerFunc) *FuncController {
+	return c.Handle(http.MethodPost, relPath, handlers...)
+}
+
+// PUT 登记 PUT 路由
+func (c *FuncController) PUT(relPath string, handlers ...gin.HandlerFunc) *FuncController {
+	return c.Handle(http.MethodPut, relPath, handlers...)
+}
+
+// PATCH 登记 PATCH 路由
+func (c *FuncController) PATCH(relPath string, handlers ...gin.HandlerFunc) *FuncController {
+	return c.Handle(http.MethodPatch, relPath, handlers...)
+}
+
+// DELETE 登记 DELETE 路由
+func (c *FuncController) DELETE(relPath string, handlers ...gin.HandlerFunc) *FuncController {
+	return c.Handle(http.MethodDelete, relPath, handlers...)
+}
+
+// Provider 返回该控制器的提供者，供 Engine.AddController 使用
+func (c *FuncController) Provider() ControllerProvider {
+	return Provider(c)
+}
+
+// RegisterRoutes 实现 Controller 接口：按 分组中间件 -> 共享中间件 -> Use 中间件 的顺序挂载后注册路由
+func (c *FuncController) RegisterRoutes(router gin.IRouter, mg *MiddlewareManager, _ *Engine) {
+	handlers := make([]gin.HandlerFunc, 0)
+	for _, name := range c.groupNames {
+		handlers = append(handlers, mg.MustGroup(name)...)
+	}
+	for _, name := range c.sharedNames {
+		handlers = append(handlers, mg.MustShared(name))
+	}
+	handlers = append(handlers, c.middlewares...)
+
+	rg := router.Group(c.prefix, handlers...)
+	for _, r := range c.routes {
+		rg.Handle(r.method, r.path, r.handlers...)
+	}
+}
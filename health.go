@@ -0,0 +1,173 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 健康检查默认值
+const (
+	defaultLivenessPath  = "/healthz"
+	defaultReadinessPath = "/readyz"
+	defaultHealthTimeout = 3 * time.Second
+)
+
+// HealthCheck 就绪检查项
+type HealthCheck interface {
+	// Name 检查项名称，出现在 /readyz 响应中
+	Name() string
+	// Check 执行检查，返回非 nil 表示未就绪
+	Check(ctx context.Context) error
+}
+
+// HealthCheckFunc 函数形式的就绪检查项
+type HealthCheckFunc struct {
+	CheckName string
+	Fn        func(ctx context.Context) error
+}
+
+// Name 检查项名称
+func (f HealthCheckFunc) Name() string { return f.CheckName }
+
+// Check 执行检查
+func (f HealthCheckFunc) Check(ctx context.Context) error { return f.Fn(ctx) }
+
+// HealthOptions 健康检查端点配置
+type HealthOptions struct {
+	LivenessPath         string        // 存活探针路径，默认 /healthz
+	ReadinessPath        string        // 就绪探针路径，默认 /readyz
+	Timeout              time.Duration // 单次就绪检查的超时时间，默认 3s
+	Checks               []HealthCheck // 额外的就绪检查项
+	DisableDefaultChecks bool          // 不注册内置检查项（数据库连通性、协程池可用性）
+}
+
+// HealthCheckResult 单个检查项的结果
+type HealthCheckResult struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// EnableHealthEndpoints 启用 /healthz 与 /readyz 内置端点，应在 Run 之前调用
+// 端点直接挂载在根路由上（不受 basePath 与全局/认证中间件影响），并先于控制器挂载：
+//   - 存活探针：服务运行即返回 200
+//   - 就绪探针：并发执行全部检查项，任一失败返回 503 并列出失败项
+func (e *Engine) EnableHealthEndpoints(opts HealthOptions) {
+	if opts.LivenessPath == "" {
+		opts.LivenessPath = defaultLivenessPath
+	}
+	if opts.ReadinessPath == "" {
+		opts.ReadinessPath = defaultReadinessPath
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultHealthTimeout
+	}
+
+	e.healthMu.Lock()
+	defer e.healthMu.Unlock()
+	e.healthOptions = &opts
+	if !opts.DisableDefaultChecks {
+		e.healthChecks = append(e.healthChecks, dbHealthCheck(e), poolHealthCheck(e))
+	}
+	e.healthChecks = append(e.healthChecks, opts.Checks...)
+}
+
+// AddHealthCheck 追加就绪检查项
+func (e *Engine) AddHealthCheck(checks ...HealthCheck) {
+	e.healthMu.Lock()
+	defer e.healthMu.Unlock()
+	e.healthChecks = append(e.healthChecks, checks...)
+}
+
+// mountHealthEndpoints 挂载健康检查端点（仅启动阶段，未启用时跳过）
+func (e *Engine) mountHealthEndpoints() {
+	e.healthMu.RLock()
+	opts := e.healthOptions
+	e.healthMu.RUnlock()
+	if opts == nil {
+		return
+	}
+
+	e.router.GET(opts.LivenessPath, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, Response[gin.H]{Msg: "ok"})
+	})
+	e.router.GET(opts.ReadinessPath, func(ctx *gin.Context) {
+		failed := e.runHealthChecks(ctx.Request.Context(), opts.Timeout)
+		if len(failed) > 0 {
+			ctx.JSON(http.StatusServiceUnavailable, Response[gin.H]{
+				Code: ErrorCode(http.StatusServiceUnavailable),
+				Msg:  "服务未就绪",
+				Data: gin.H{"failed": failed},
+			})
+			return
+		}
+		ctx.JSON(http.StatusOK, Response[gin.H]{Msg: "ok"})
+	})
+}
+
+// runHealthChecks 并发执行全部就绪检查项，返回失败项（按注册顺序）
+func (e *Engine) runHealthChecks(ctx context.Context, timeout time.Duration) []HealthCheckResult {
+	e.healthMu.RLock()
+	checks := append([]HealthCheck(nil), e.healthChecks...)
+	e.healthMu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = runHealthCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	failed := make([]HealthCheckResult, 0)
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, HealthCheckResult{Name: checks[i].Name(), Error: err.Error()})
+		}
+	}
+	return failed
+}
+
+// runHealthCheck 执行单个检查项，panic 视为检查失败
+func runHealthCheck(ctx context.Context, check HealthCheck) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("检查项发生 panic")
+		}
+	}()
+	return check.Check(ctx)
+}
+
+// dbHealthCheck 数据库连通性检查，未配置数据库时视为通过
+func dbHealthCheck(e *Engine) HealthCheck {
+	return HealthCheckFunc{CheckName: "database", Fn: func(ctx context.Context) error {
+		if e.db == nil {
+			return nil
+		}
+		sqlDB, err := e.db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}}
+}
+
+// poolHealthCheck 协程池可用性检查
+func poolHealthCheck(e *Engine) HealthCheck {
+	return HealthCheckFunc{CheckName: "pool", Fn: func(context.Context) error {
+		if e.pool == nil || e.pool.IsClosed() {
+			return errors.New("协程池不可用")
+		}
+		return nil
+	}}
+}
@@ -5,11 +5,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
 )
 
-const contextKeyI18nLocalizer = "abe.i18n.localizer"
+const (
+	contextKeyI18nLocalizer  = "abe.i18n.localizer"
+	contextKeyI18nCandidates = "abe.i18n.candidates"
+)
 
 // i18nMiddleware 根据配置解析语言偏好，在请求上下文中注入 Localizer
+// 优先级：查询参数 > Cookie（配置 i18n.lang_cookie 时）> 请求头（按 q 值排序展开）> 默认语言
 func i18nMiddleware(e *Engine) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		cfg := e.Config()
@@ -21,25 +26,49 @@ func i18nMiddleware(e *Engine) gin.HandlerFunc {
 		if langHeaderKey == "" {
 			langHeaderKey = "Accept-Language"
 		}
+		langCookie := cfg.GetString("i18n.lang_cookie")
 		defaultLang := cfg.GetString("i18n.default_language")
 
 		var candidates []string
 		if qv := strings.TrimSpace(ctx.Query(langQueryKey)); qv != "" {
 			candidates = append(candidates, qv)
 		}
+		if langCookie != "" {
+			if cv, err := ctx.Cookie(langCookie); err == nil && strings.TrimSpace(cv) != "" {
+				candidates = append(candidates, strings.TrimSpace(cv))
+			}
+		}
 		if hv := strings.TrimSpace(ctx.GetHeader(langHeaderKey)); hv != "" {
-			candidates = append(candidates, hv)
+			candidates = append(candidates, acceptLanguageCandidates(hv)...)
 		}
 		if defaultLang != "" {
 			candidates = append(candidates, defaultLang)
 		}
 
-		localizer := i18n.NewLocalizer(e.i18nBundle, candidates...)
+		localizer := i18n.NewLocalizer(e.currentI18nBundle(), candidates...)
 		ctx.Set(contextKeyI18nLocalizer, localizer)
+		ctx.Set(contextKeyI18nCandidates, candidates)
 		ctx.Next()
 	}
 }
 
+// acceptLanguageCandidates 将 Accept-Language 形式的请求头按 q 值从高到低展开为语言列表
+// q=0 与通配符 * 被忽略；无法解析时原样作为单个候选（兼容自定义请求头直接传语言名）
+func acceptLanguageCandidates(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return []string{header}
+	}
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		// 通配符 * 解析为 mul（多语言），不对应具体翻译
+		if tag != language.Und && tag.String() != "mul" {
+			out = append(out, tag.String())
+		}
+	}
+	return out
+}
+
 // Localizer 从 gin.Context 中获取 Localizer
 func Localizer(ctx *gin.Context) *i18n.Localizer {
 	v, ok := ctx.Get(contextKeyI18nLocalizer)
@@ -62,3 +91,13 @@ func Localize(ctx *gin.Context, config *i18n.LocalizeConfig) string {
 	}
 	return msg
 }
+
+// languageCandidates 返回 i18nMiddleware 解析出的语言偏好（按优先级排列：查询参数、Cookie、请求头、默认语言）
+func languageCandidates(ctx *gin.Context) []string {
+	v, ok := ctx.Get(contextKeyI18nCandidates)
+	if !ok {
+		return nil
+	}
+	candidates, _ := v.([]string)
+	return candidates
+}
@@ -0,0 +1,115 @@
+package abe
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// LocalizedError 携带翻译消息 ID 的业务错误
+// 错误处理中间件按请求语言（见 i18nMiddleware）将 MessageID 翻译为响应消息，翻译缺失时使用 Message
+type LocalizedError struct {
+	Err          error          // 哨兵错误（如 ErrBadRequest），决定 HTTP 状态码
+	MessageID    string         // 翻译消息 ID
+	TemplateData map[string]any // 翻译模板数据
+	Message      string         // 翻译缺失时的回退消息，为空时使用 MessageID
+}
+
+func (e *LocalizedError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.MessageID
+}
+
+func (e *LocalizedError) Unwrap() error {
+	return e.Err
+}
+
+// NewLocalizedError 构造携带翻译消息 ID 的错误
+//
+// 使用示例:
+//
+//	return nil, abe.NewLocalizedError(abe.ErrForbidden, "order.not_owner", map[string]any{"OrderID": id})
+func NewLocalizedError(sentinel error, messageID string, templateData map[string]any) *LocalizedError {
+	return &LocalizedError{Err: sentinel, MessageID: messageID, TemplateData: templateData}
+}
+
+// BadRequestID 构造 400 翻译错误
+func BadRequestID(messageID string, templateData map[string]any) *LocalizedError {
+	return NewLocalizedError(ErrBadRequest, messageID, templateData)
+}
+
+// UnauthorizedID 构造 401 翻译错误
+func UnauthorizedID(messageID string, templateData map[string]any) *LocalizedError {
+	return NewLocalizedError(ErrUnauthorized, messageID, templateData)
+}
+
+// ForbiddenID 构造 403 翻译错误
+func ForbiddenID(messageID string, templateData map[string]any) *LocalizedError {
+	return NewLocalizedError(ErrForbidden, messageID, templateData)
+}
+
+// WithMessage 设置翻译缺失时的回退消息
+func (e *LocalizedError) WithMessage(message string) *LocalizedError {
+	e.Message = message
+	return e
+}
+
+// LocalizedErrorHandler 将 LocalizedError 渲染为错误响应的错误处理器，状态码由包装的哨兵错误决定
+// 响应消息在错误处理中间件中按请求语言翻译
+//
+// 使用示例:
+//
+//	engine.AddErrorHandler(abe.LocalizedErrorHandler)
+func LocalizedErrorHandler(err error) (*ErrorResponse, int) {
+	var le *LocalizedError
+	if !errors.As(err, &le) {
+		return nil, 0
+	}
+	status := sentinelStatus(le.Err)
+	return &ErrorResponse{Code: ErrorCode(status), Msg: le.Error()}, status
+}
+
+// localizeErrorResponse 错误链中存在 LocalizedError 时，按请求语言翻译响应消息
+func localizeErrorResponse(ctx *gin.Context, err error, resp *ErrorResponse) *ErrorResponse {
+	var le *LocalizedError
+	if !errors.As(err, &le) || le.MessageID == "" {
+		return resp
+	}
+	localizer := Localizer(ctx)
+	if localizer == nil {
+		return resp
+	}
+	msg, lerr := localizer.Localize(&i18n.LocalizeConfig{MessageID: le.MessageID, TemplateData: le.TemplateData})
+	if lerr != nil || msg == "" {
+		return resp
+	}
+	localized := *resp
+	localized.Msg = msg
+	return &localized
+}
+
+// sentinelStatus 哨兵错误对应的 HTTP 状态码，未知错误视为 500
+func sentinelStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrResourceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTooManyRequests):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrURITooLong):
+		return http.StatusRequestURITooLong
+	case errors.Is(err, ErrGatewayTimeout):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,63 @@
+package abe
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contextKeyLogFields 上下文键约定：存放访问日志的业务字段累加器
+const contextKeyLogFields = "abe.log_fields"
+
+// maxLogFields 单个请求可追加的业务字段上限，超出部分丢弃，防止日志膨胀
+const maxLogFields = 32
+
+// logFields 访问日志业务字段累加器（请求内共享指针，并发安全）
+type logFields struct {
+	mu      sync.Mutex
+	attrs   []slog.Attr
+	dropped int
+}
+
+// AddLogField 为当前请求的访问日志追加业务字段（如受影响的资源 ID），请求结束时随访问日志一并输出
+// 同名字段以最后一次为准；每个请求最多 32 个字段，超出部分丢弃并在日志中记录丢弃数量
+//
+// 使用示例:
+//
+//	abe.AddLogField(ctx, "order_id", order.ID)
+func AddLogField(ctx *gin.Context, key string, value any) {
+	v, ok := ctx.Get(contextKeyLogFields)
+	if !ok {
+		return
+	}
+	f, ok := v.(*logFields)
+	if !ok {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.attrs {
+		if f.attrs[i].Key == key {
+			f.attrs[i] = slog.Any(key, value)
+			return
+		}
+	}
+	if len(f.attrs) >= maxLogFields {
+		f.dropped++
+		return
+	}
+	f.attrs = append(f.attrs, slog.Any(key, value))
+}
+
+// snapshot 返回已追加字段的副本；有字段被丢弃时追加 log_fields_dropped
+func (f *logFields) snapshot() []slog.Attr {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	attrs := append([]slog.Attr(nil), f.attrs...)
+	if f.dropped > 0 {
+		attrs = append(attrs, slog.Int("log_fields_dropped", f.dropped))
+	}
+	return attrs
+}
@@ -0,0 +1,53 @@
+package abe
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contextKeyLogger 上下文键约定：存放请求级日志记录器
+const contextKeyLogger = "abe.logger"
+
+// LoggerContextMiddleware 为每个请求派生携带 request_id 的日志记录器并写入上下文，
+// 处理器通过 LoggerFrom 获取后记录的日志可与访问日志按 request_id 关联
+// 引擎挂载控制器时已在请求 ID 中间件之后自动注册，通常无需手动使用
+func LoggerContextMiddleware(e *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		logger := e.Logger()
+		if id := GetRequestID(ctx); id != "" {
+			logger = logger.With("request_id", id)
+		}
+		ctx.Set(contextKeyLogger, logger)
+		ctx.Next()
+	}
+}
+
+// LoggerFrom 获取请求级日志记录器；未经过 LoggerContextMiddleware 时返回 slog.Default()
+//
+// 使用示例:
+//
+//	abe.LoggerFrom(ctx).Info("订单已创建", "order_id", order.ID)
+func LoggerFrom(ctx *gin.Context) *slog.Logger {
+	return requestLogger(ctx, slog.Default())
+}
+
+// requestLogger 获取请求级日志记录器，不存在时返回 fallback
+func requestLogger(ctx *gin.Context, fallback *slog.Logger) *slog.Logger {
+	if v, ok := ctx.Get(contextKeyLogger); ok {
+		if l, ok := v.(*slog.Logger); ok && l != nil {
+			return l
+		}
+	}
+	return fallback
+}
+
+// withRequestLogger 为已存在的请求级日志记录器追加属性（如 trace_id），不存在时返回 false
+func withRequestLogger(ctx *gin.Context, args ...any) bool {
+	l := requestLogger(ctx, nil)
+	if l == nil {
+		return false
+	}
+	ctx.Set(contextKeyLogger, l.With(args...))
+	return true
+}
@@ -0,0 +1,84 @@
+package abe
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute 未匹配任何路由（404）的请求使用的 route 标签值
+const unmatchedRoute = "unmatched"
+
+var (
+	httpMetricsOnce sync.Once
+
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "abe_http_requests_total",
+		Help: "HTTP 请求总数",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "abe_http_request_duration_seconds",
+		Help:    "HTTP 请求处理耗时（秒）",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// MetricsMiddleware HTTP 请求指标中间件，记录请求数与耗时直方图（注册到 Prometheus 默认注册表）
+// 标签：method、route、status；route 使用路由模板（ctx.FullPath()，如 "/users/:id"）而非原始路径，
+// 避免路径参数导致标签基数膨胀
+//
+// 使用示例:
+//
+//	engine.MiddlewareManager().RegisterGlobal(abe.MetricsMiddleware())
+//	engine.Router().GET("/metrics", abe.MetricsHandler())
+func MetricsMiddleware() gin.HandlerFunc {
+	httpMetricsOnce.Do(func() {
+		prometheus.MustRegister(httpRequestsTotal, httpRequestDuration)
+	})
+
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(ctx.Writer.Status())
+		httpRequestsTotal.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// MetricsHandler 暴露 Prometheus 默认注册表中全部指标的处理器
+func MetricsHandler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// RegisterCollectors 向 Prometheus 默认注册表注册自定义指标，已注册的同一指标会被忽略
+//
+// 使用示例:
+//
+//	ordersCreated := prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_created_total"})
+//	if err := abe.RegisterCollectors(ordersCreated); err != nil {
+//	    return err
+//	}
+func RegisterCollectors(collectors ...prometheus.Collector) error {
+	var errs []error
+	for _, c := range collectors {
+		if err := prometheus.Register(c); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if errors.As(err, &are) && are.ExistingCollector == c {
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
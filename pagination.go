@@ -0,0 +1,93 @@
+package abe
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize 未传 page_size 时的每页条数
+	DefaultPageSize = 20
+	// MaxPageSize 每页条数上限，超出时按上限截断
+	MaxPageSize = 100
+)
+
+// PageQuery 分页查询参数，页码从 1 开始
+type PageQuery struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// Offset 返回当前页的偏移量
+func (q PageQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// BindPageQuery 从查询参数 page、page_size 读取分页参数
+// 未传时 page 为 1、page_size 为 DefaultPageSize；page_size 超过 MaxPageSize 时截断为上限；
+// 非整数或小于 1 时返回 FieldErrors（包装 ErrBadRequest）
+//
+// 使用示例:
+//
+//	q, err := abe.BindPageQuery(ctx)
+//	if err != nil {
+//	    return nil, err
+//	}
+//	var users []User
+//	var total int64
+//	db.Model(&User{}).Count(&total)
+//	abe.Paginate(db, q).Find(&users)
+//	return abe.NewPageResult(users, total, q), nil
+func BindPageQuery(ctx *gin.Context) (PageQuery, error) {
+	q := PageQuery{Page: 1, PageSize: DefaultPageSize}
+	var errs FieldErrors
+
+	if v := strings.TrimSpace(ctx.Query("page")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			errs = errs.Add("page", "page 必须是大于 0 的整数")
+		} else {
+			q.Page = n
+		}
+	}
+	if v := strings.TrimSpace(ctx.Query("page_size")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			errs = errs.Add("page_size", "page_size 必须是大于 0 的整数")
+		} else {
+			q.PageSize = min(n, MaxPageSize)
+		}
+	}
+
+	if len(errs) > 0 {
+		return PageQuery{}, errs
+	}
+	return q, nil
+}
+
+// PageResult 分页列表响应体
+type PageResult[T any] struct {
+	Items    []T   `json:"items"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+}
+
+// NewPageResult 按分页参数构造分页结果，items 为 nil 时序列化为空数组
+func NewPageResult[T any](items []T, total int64, q PageQuery) PageResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return PageResult[T]{Items: items, Total: total, Page: q.Page, PageSize: q.PageSize}
+}
+
+// Paginate 为查询附加分页条件（OFFSET/LIMIT）
+// 统计总数应在调用前完成，避免 Count 受 LIMIT 影响；也可以作为 scope 使用：
+//
+//	db.Scopes(func(tx *gorm.DB) *gorm.DB { return abe.Paginate(tx, q) }).Find(&users)
+func Paginate(db *gorm.DB, q PageQuery) *gorm.DB {
+	return db.Offset(q.Offset()).Limit(q.PageSize)
+}
@@ -0,0 +1,145 @@
+package abe
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionSpec 路由权限声明
+type PermissionSpec struct {
+	Resource string // 资源标识
+	Action   string // 操作标识
+}
+
+// Permission 构造路由权限声明
+//
+// 使用示例:
+//
+//	g.GET("/users", abe.Permission("user", "read"), ctrl.List)
+func Permission(resource, action string) *PermissionSpec {
+	return &PermissionSpec{Resource: resource, Action: action}
+}
+
+// RoutePermission 路由与权限的映射记录
+type RoutePermission struct {
+	Method   string // HTTP 方法
+	Path     string // 完整路由模板（含分组前缀）
+	Resource string // 资源标识
+	Action   string // 操作标识
+}
+
+// PermissionRouterGroup 带权限声明的路由分组
+// 注册路由时可同时声明权限：自动挂载 AuthorizationMiddleware，并将映射记录到引擎，
+// 使路由定义与权限定义保持在同一处，避免二者不一致导致的 403。
+// 权限参数传 nil 表示公开路由，不挂载鉴权中间件。
+//
+// 注意：鉴权中间件依赖上下文中的用户声明，需在分组或全局中先挂载 AuthenticationMiddleware。
+type PermissionRouterGroup struct {
+	rg     *gin.RouterGroup
+	engine *Engine
+}
+
+// NewPermissionRouterGroup 基于 gin 路由分组创建带权限声明的路由分组
+//
+// 使用示例:
+//
+//	func (c *UserController) RegisterRoutes(rg *gin.RouterGroup, mg *abe.MiddlewareManager, e *abe.Engine) {
+//	    g := abe.NewPermissionRouterGroup(e, rg).Group("/users", abe.AuthenticationMiddleware[*MyClaims](e))
+//	    g.GET("", abe.Permission("user", "read"), c.List)
+//	    g.POST("", abe.Permission("user", "create"), c.Create)
+//	    g.GET("/public-profile/:id", nil, c.PublicProfile)
+//	}
+func NewPermissionRouterGroup(engine *Engine, rg *gin.RouterGroup) *PermissionRouterGroup {
+	return &PermissionRouterGroup{rg: rg, engine: engine}
+}
+
+// RouterGroup 返回底层的 gin 路由分组
+func (g *PermissionRouterGroup) RouterGroup() *gin.RouterGroup {
+	return g.rg
+}
+
+// Group 创建子分组
+func (g *PermissionRouterGroup) Group(relativePath string, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	return &PermissionRouterGroup{rg: g.rg.Group(relativePath, handlers...), engine: g.engine}
+}
+
+// Use 为当前分组追加中间件
+func (g *PermissionRouterGroup) Use(handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	g.rg.Use(handlers...)
+	return g
+}
+
+// Handle 注册路由；perm 非 nil 时在处理器前挂载 AuthorizationMiddleware 并记录权限映射
+func (g *PermissionRouterGroup) Handle(method, relativePath string, perm *PermissionSpec, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	if perm != nil {
+		chain := make([]gin.HandlerFunc, 0, len(handlers)+1)
+		chain = append(chain, AuthorizationMiddleware(g.engine, perm.Resource, perm.Action))
+		chain = append(chain, handlers...)
+		handlers = chain
+
+		g.engine.addRoutePermission(RoutePermission{
+			Method:   method,
+			Path:     joinRoutePath(g.rg.BasePath(), relativePath),
+			Resource: perm.Resource,
+			Action:   perm.Action,
+		})
+	}
+	g.rg.Handle(method, relativePath, handlers...)
+	return g
+}
+
+// GET 注册 GET 路由
+func (g *PermissionRouterGroup) GET(relativePath string, perm *PermissionSpec, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	return g.Handle(http.MethodGet, relativePath, perm, handlers...)
+}
+
+// POST 注册 POST 路由
+func (g *PermissionRouterGroup) POST(relativePath string, perm *PermissionSpec, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	return g.Handle(http.MethodPost, relativePath, perm, handlers...)
+}
+
+// PUT 注册 PUT 路由
+func (g *PermissionRouterGroup) PUT(relativePath string, perm *PermissionSpec, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	return g.Handle(http.MethodPut, relativePath, perm, handlers...)
+}
+
+// PATCH 注册 PATCH 路由
+func (g *PermissionRouterGroup) PATCH(relativePath string, perm *PermissionSpec, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	return g.Handle(http.MethodPatch, relativePath, perm, handlers...)
+}
+
+// DELETE 注册 DELETE 路由
+func (g *PermissionRouterGroup) DELETE(relativePath string, perm *PermissionSpec, handlers ...gin.HandlerFunc) *PermissionRouterGroup {
+	return g.Handle(http.MethodDelete, relativePath, perm, handlers...)
+}
+
+// RoutePermissions 返回通过 PermissionRouterGroup 注册的路由权限映射快照
+// 可用于启动后输出权限清单、初始化策略或与 Casbin 策略做一致性校验
+func (e *Engine) RoutePermissions() []RoutePermission {
+	e.routePermissionsMu.RLock()
+	defer e.routePermissionsMu.RUnlock()
+	cp := make([]RoutePermission, len(e.routePermissions))
+	copy(cp, e.routePermissions)
+	return cp
+}
+
+func (e *Engine) addRoutePermission(rp RoutePermission) {
+	e.routePermissionsMu.Lock()
+	defer e.routePermissionsMu.Unlock()
+	e.routePermissions = append(e.routePermissions, rp)
+}
+
+// joinRoutePath 拼接分组前缀与相对路径，保留相对路径末尾的斜杠（与 gin 行为一致）
+func joinRoutePath(base, relative string) string {
+	if relative == "" {
+		return base
+	}
+	joined := path.Join(base, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
@@ -8,12 +8,13 @@ package abe
 import (
 	"errors"
 	"fmt"
-	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
 )
 
 // Plugin ABE插件
@@ -53,11 +54,34 @@ type AfterMountHook interface {
 	OnAfterMount(engine *Engine) error
 }
 
+// UnloadHook 在启动阶段发生致命错误需要回滚时触发（按初始化逆序）
+// 适合做：释放 Init 阶段获取的连接、文件句柄等资源
+type UnloadHook interface {
+	OnUnload(engine *Engine) error
+}
+
 // EngineVersionRequirement 可选：声明对 abe 引擎的最低版本要求（SemVer，形如 x.y.z）
 type EngineVersionRequirement interface {
 	MinEngineVersion() string
 }
 
+// PluginConfig 返回插件专属配置子树（plugins.config.<name>），插件可在 Init 中获取，避免与其他配置键冲突
+// 未配置时返回空的 viper 实例，读取任意键均得到零值；返回值为调用时的快照，配置热更新后需重新获取
+//
+// 使用示例:
+//
+//	func (p *CachePlugin) Init(engine *abe.Engine) error {
+//	    cfg := engine.PluginConfig(p.Name()) // 对应 plugins.config.cache.*
+//	    p.ttl = cfg.GetDuration("ttl")
+//	    return nil
+//	}
+func (e *Engine) PluginConfig(name string) *viper.Viper {
+	if sub := e.Config().Sub("plugins.config." + name); sub != nil {
+		return sub
+	}
+	return viper.New()
+}
+
 // PluginManager 插件管理器，负责插件注册与钩子调度
 type PluginManager struct {
 	mu         sync.RWMutex
@@ -67,6 +91,11 @@ type PluginManager struct {
 	alias      map[string]string   // key -> alias
 	aliasIndex map[string]string   // alias -> key
 	nameIndex  map[string][]string // name -> keys
+	pending    map[string]struct{} // 正在执行 Init 的插件唯一键
+	disabled   map[string]struct{} // 运行时禁用的插件唯一键，钩子调度时跳过
+	dispatch   int                 // 正在执行的钩子调度数，期间禁止 Unregister
+
+	hookResults map[string]map[string]PluginHookResult // key -> 阶段 -> 最近一次结果，见 Status
 }
 
 func newPluginManager(engine *Engine) *PluginManager {
@@ -76,21 +105,31 @@ func newPluginManager(engine *Engine) *PluginManager {
 		alias:      make(map[string]string),
 		aliasIndex: make(map[string]string),
 		nameIndex:  make(map[string][]string),
+		pending:    make(map[string]struct{}),
+		disabled:   make(map[string]struct{}),
+
+		hookResults: make(map[string]map[string]PluginHookResult),
 	}
 }
 
 // Register 注册插件，并立即调用其 Init(engine)
-// 若同名插件已存在则返回错误，不重复注册
+// 若同名插件已存在则返回错误，不重复注册；插件声明的依赖（PluginDependencies）未注册时返回错误，
+// 需要按依赖自动排序时使用 RegisterAll
+//
+// 并发与重入：
+//   - 启用判定、版本校验与依赖校验不持有管理器锁
+//   - 随后加锁检查重复（含正在执行 Init 的插件）并解析名称/别名冲突，为唯一键登记占位（pending）后释放锁，防止并发重复注册
+//   - Init 在不持有锁的情况下调用，插件可在 Init 中安全调用 List、LookupByKey 等方法，甚至注册依赖插件
+//   - Init 完成后重新加锁移除占位、写入索引，并复核 Init 期间是否出现新的名称或别名冲突；复核拒绝时调用插件的 UnloadHook
+//
+// 启动回滚：Init 返回错误视为致命的启动失败，会先调用 Rollback 按逆序卸载已初始化的插件再返回该错误
 func (pm *PluginManager) Register(p Plugin) error {
 	if p == nil {
 		return nil
 	}
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
 
-	// 计算唯一键（包路径 + 类型名）
-	t := reflect.TypeOf(p)
-	key := t.PkgPath() + "." + t.Name()
+	// 计算唯一键（包路径 + 类型名，指针类型取其元素类型）
+	key := pluginKey(p)
 	name := p.Name()
 
 	// 启用配置判定：默认启用，可通过 plugins.enabled 与 plugins.enable.<unique_key> 覆盖
@@ -107,31 +146,32 @@ func (pm *PluginManager) Register(p Plugin) error {
 		return nil
 	}
 
-	// 重复插件（按唯一键）直接拒绝
-	if _, ok := pm.index[key]; ok {
-		return errDuplicatePlugin(key)
-	}
-
 	// 兼容性校验：若插件声明 MinEngineVersion 且当前 abe 版本不满足
 	minEngine := ""
 	if req, ok := p.(EngineVersionRequirement); ok {
 		minEngine = strings.TrimSpace(req.MinEngineVersion())
 	}
 	if minEngine != "" {
-		current, err1 := semver.NewVersion(Version)
+		engineVersion := pm.engine.BuildInfo().Version
+		current, err1 := semver.NewVersion(engineVersion)
 		constraint, err2 := semver.NewConstraint(">= " + minEngine)
 		if err1 != nil || err2 != nil {
-			pm.engine.Logger().Warn("版本字符串解析失败，继续注册", "name", name, "unique_key", key, "engine_version", Version, "required_min", minEngine, "error", fmt.Sprintf("%v %v", err1, err2))
+			pm.engine.Logger().Warn("版本字符串解析失败，继续注册", "name", name, "unique_key", key, "engine_version", engineVersion, "required_min", minEngine, "error", fmt.Sprintf("%v %v", err1, err2))
 		} else if !constraint.Check(current) {
 			strict := pm.engine.Config().GetBool("plugins.compat.strict")
 			if strict {
-				pm.engine.Logger().Error("插件与引擎版本不兼容，拒绝注册", "name", name, "unique_key", key, "engine_version", Version, "required_min", minEngine)
-				return fmt.Errorf("engine version %s does not satisfy >= %s for plugin %s", Version, minEngine, name)
+				pm.engine.Logger().Error("插件与引擎版本不兼容，拒绝注册", "name", name, "unique_key", key, "engine_version", engineVersion, "required_min", minEngine)
+				return fmt.Errorf("engine version %s does not satisfy >= %s for plugin %s", engineVersion, minEngine, name)
 			}
-			pm.engine.Logger().Warn("插件与引擎版本不兼容，继续注册", "name", name, "unique_key", key, "engine_version", Version, "required_min", minEngine)
+			pm.engine.Logger().Warn("插件与引擎版本不兼容，继续注册", "name", name, "unique_key", key, "engine_version", engineVersion, "required_min", minEngine)
 		}
 	}
 
+	// 依赖校验：声明的依赖插件须已注册
+	if err := pm.checkDependencies(p, key); err != nil {
+		return err
+	}
+
 	// 冲突模式：默认 alias，可配置 plugins.conflict_mode=error
 	mode := strings.ToLower(pm.engine.Config().GetString("plugins.conflict_mode"))
 	if mode == "" {
@@ -139,24 +179,48 @@ func (pm *PluginManager) Register(p Plugin) error {
 	}
 
 	// 读取别名覆盖配置：plugins.aliases.<key>
-	alias := pm.normalizeAlias(pm.engine.Config().GetString("plugins.aliases." + key))
+	configuredAlias := pm.normalizeAlias(pm.engine.Config().GetString("plugins.aliases." + key))
 
-	// 检查名称冲突
-	conflictKeys := pm.nameIndex[name]
-	hasConflict := len(conflictKeys) > 0
+	pm.mu.Lock()
+	// 重复插件（按唯一键，含正在初始化的插件）直接拒绝
+	if _, ok := pm.index[key]; ok {
+		pm.mu.Unlock()
+		return errDuplicatePlugin(key)
+	}
+	if _, ok := pm.pending[key]; ok {
+		pm.mu.Unlock()
+		return errDuplicatePlugin(key)
+	}
+	alias, err := pm.resolveAliasLocked(name, key, configuredAlias, mode)
+	if err != nil {
+		pm.mu.Unlock()
+		return err
+	}
+	pm.pending[key] = struct{}{}
+	pm.mu.Unlock()
 
-	if alias == "" && hasConflict {
-		if mode == "error" {
-			pm.engine.Logger().Error("插件名称冲突，拒绝注册", "name", name, "unique_key", key, "conflict_with", conflictKeys, "hint", "设置 plugins.conflict_mode=alias 或配置 plugins.aliases.<key> 指定别名")
-			return errDuplicatePlugin(name)
-		}
-		// alias 模式：生成稳定别名并 WARN
-		alias = pm.generateAlias(name, key)
-		pm.engine.Logger().Warn("插件名称冲突，使用别名", "name", name, "assigned_alias", alias, "unique_key", key, "conflict_with", conflictKeys, "hint", "设置 plugins.conflict_mode=error 可阻止注册；或通过 plugins.aliases.<key> 覆盖别名")
+	// 初始化插件（不持有管理器锁，允许 Init 内重入插件管理器）
+	initStart := time.Now()
+	if err := p.Init(pm.engine); err != nil {
+		pm.mu.Lock()
+		delete(pm.pending, key)
+		pm.mu.Unlock()
+		pm.engine.Logger().Error("插件初始化失败", "plugin", name, "unique_key", key, "error", err)
+		pm.Rollback()
+		return err
 	}
 
-	// 别名唯一性保证
-	if alias != "" {
+	pm.mu.Lock()
+	delete(pm.pending, key)
+
+	// 复核：Init 期间可能有其他插件占用了同名或同别名
+	if alias == "" && len(pm.nameIndex[name]) > 0 {
+		if alias, err = pm.resolveAliasLocked(name, key, "", mode); err != nil {
+			pm.mu.Unlock()
+			pm.unloadRejected(p, key)
+			return err
+		}
+	} else if alias != "" {
 		if _, exists := pm.aliasIndex[alias]; exists {
 			newAlias := pm.makeAliasUnique(alias)
 			pm.engine.Logger().Warn("插件别名冲突，调整别名", "original_alias", alias, "new_alias", newAlias, "unique_key", key)
@@ -164,13 +228,8 @@ func (pm *PluginManager) Register(p Plugin) error {
 		}
 	}
 
-	// 初始化插件
-	if err := p.Init(pm.engine); err != nil {
-		pm.engine.Logger().Error("插件初始化失败", "plugin", name, "unique_key", key, "error", err)
-		return err
-	}
-
 	// 记录索引与元数据
+	pm.hookResults[key] = map[string]PluginHookResult{"init": {OK: true, Duration: time.Since(initStart), At: time.Now()}}
 	pm.plugins = append(pm.plugins, p)
 	pm.index[key] = p
 	pm.nameIndex[name] = append(pm.nameIndex[name], key)
@@ -178,6 +237,7 @@ func (pm *PluginManager) Register(p Plugin) error {
 		pm.alias[key] = alias
 		pm.aliasIndex[alias] = key
 	}
+	pm.mu.Unlock()
 
 	// 成功日志：展示名优先 alias，其次 name
 	display := alias
@@ -189,6 +249,50 @@ func (pm *PluginManager) Register(p Plugin) error {
 	return nil
 }
 
+// resolveAliasLocked 根据冲突模式计算插件别名（调用方需持有写锁）
+// 名称冲突且为 error 模式时返回错误；alias 模式下生成稳定别名；别名已被占用时追加后缀
+func (pm *PluginManager) resolveAliasLocked(name, key, alias, mode string) (string, error) {
+	// 检查名称冲突
+	conflictKeys := pm.nameIndex[name]
+	hasConflict := len(conflictKeys) > 0
+
+	if alias == "" && hasConflict {
+		if mode == "error" {
+			pm.engine.Logger().Error("插件名称冲突，拒绝注册", "name", name, "unique_key", key, "conflict_with", conflictKeys, "hint", "设置 plugins.conflict_mode=alias 或配置 plugins.aliases.<key> 指定别名")
+			return "", errDuplicatePlugin(name)
+		}
+		// alias 模式：生成稳定别名并 WARN
+		alias = pm.generateAlias(name, key)
+		pm.engine.Logger().Warn("插件名称冲突，使用别名", "name", name, "assigned_alias", alias, "unique_key", key, "conflict_with", conflictKeys, "hint", "设置 plugins.conflict_mode=error 可阻止注册；或通过 plugins.aliases.<key> 覆盖别名")
+	}
+
+	// 别名唯一性保证
+	if alias != "" {
+		if _, exists := pm.aliasIndex[alias]; exists {
+			newAlias := pm.makeAliasUnique(alias)
+			pm.engine.Logger().Warn("插件别名冲突，调整别名", "original_alias", alias, "new_alias", newAlias, "unique_key", key)
+			alias = newAlias
+		}
+	}
+	return alias, nil
+}
+
+// unloadRejected 对已完成 Init 但最终被拒绝注册的插件调用 UnloadHook，释放其 Init 阶段获取的资源
+func (pm *PluginManager) unloadRejected(p Plugin, key string) {
+	hook, ok := p.(UnloadHook)
+	if !ok {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			pm.engine.Logger().Error("插件 Unload 发生 panic", "name", p.Name(), "unique_key", key, "panic", r)
+		}
+	}()
+	if err := hook.OnUnload(pm.engine); err != nil {
+		pm.engine.Logger().Error("插件 Unload 执行失败", "name", p.Name(), "unique_key", key, "error", err)
+	}
+}
+
 // List 返回已注册插件的快照
 func (pm *PluginManager) List() []Plugin {
 	pm.mu.RLock()
@@ -200,9 +304,7 @@ func (pm *PluginManager) List() []Plugin {
 
 // ResolveDisplayName 返回插件的展示名（通过实例计算唯一键）
 func (pm *PluginManager) ResolveDisplayName(p Plugin) string {
-	t := reflect.TypeOf(p)
-	key := t.PkgPath() + "." + t.Name()
-	return pm.resolveDisplayNameByKey(key)
+	return pm.resolveDisplayNameByKey(pluginKey(p))
 }
 
 // LookupByKey 按唯一键查找插件
@@ -228,24 +330,145 @@ func (pm *PluginManager) LookupByAliasOrName(s string) (Plugin, bool) {
 	return nil, false
 }
 
+// Disable 运行时禁用插件，之后的钩子调度（BeforeMount、AfterMount、BeforeServerStart、Shutdown）将跳过该插件
+// 插件仍保持注册状态，可通过 Enable 恢复
+func (pm *PluginManager) Disable(key string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, ok := pm.index[key]; !ok {
+		return errPluginNotFound(key)
+	}
+	pm.disabled[key] = struct{}{}
+	pm.engine.Logger().Info("插件已禁用", "unique_key", key)
+	return nil
+}
+
+// Enable 恢复被 Disable 禁用的插件
+func (pm *PluginManager) Enable(key string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, ok := pm.index[key]; !ok {
+		return errPluginNotFound(key)
+	}
+	delete(pm.disabled, key)
+	pm.engine.Logger().Info("插件已启用", "unique_key", key)
+	return nil
+}
+
+// Enabled 判断插件是否已注册且未被运行时禁用
+func (pm *PluginManager) Enabled(key string) bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	_, registered := pm.index[key]
+	_, disabled := pm.disabled[key]
+	return registered && !disabled
+}
+
+// Unregister 注销插件：从全部索引中移除，若实现 ShutdownHook 则调用其 OnShutdown（失败仅记录日志）
+// 钩子调度进行中或仍有已注册插件依赖该插件时返回错误
+func (pm *PluginManager) Unregister(key string) error {
+	pm.mu.Lock()
+	p, ok := pm.index[key]
+	if !ok {
+		pm.mu.Unlock()
+		return errPluginNotFound(key)
+	}
+	if pm.dispatch > 0 {
+		pm.mu.Unlock()
+		return fmt.Errorf("plugin hooks are running, cannot unregister %s", key)
+	}
+	refs := []string{key, p.Name(), pm.alias[key]}
+	for _, other := range pm.plugins {
+		if other == p {
+			continue
+		}
+		for _, dep := range pluginDependsOn(other) {
+			if dep != "" && slices.Contains(refs, dep) {
+				pm.mu.Unlock()
+				return fmt.Errorf("plugin %s is required by %s, cannot unregister", key, other.Name())
+			}
+		}
+	}
+
+	display := pm.resolveDisplayNameLocked(key)
+	pm.removeLocked(p, key)
+	pm.mu.Unlock()
+
+	if hook, ok := p.(ShutdownHook); ok {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					pm.engine.Logger().Error("插件 Shutdown 发生 panic", "display", display, "unique_key", key, "panic", r)
+				}
+			}()
+			if err := hook.OnShutdown(pm.engine); err != nil {
+				pm.engine.Logger().Error("插件 Shutdown 执行失败", "display", display, "unique_key", key, "error", err)
+			}
+		}()
+	}
+	pm.engine.Logger().Info("插件已注销", "display", display, "unique_key", key)
+	return nil
+}
+
+// removeLocked 从插件列表与全部索引中移除插件（调用方需持有写锁）
+func (pm *PluginManager) removeLocked(p Plugin, key string) {
+	pm.plugins = slices.DeleteFunc(pm.plugins, func(x Plugin) bool { return x == p })
+	delete(pm.index, key)
+	delete(pm.disabled, key)
+	delete(pm.hookResults, key)
+	if alias, ok := pm.alias[key]; ok {
+		delete(pm.aliasIndex, alias)
+		delete(pm.alias, key)
+	}
+	name := p.Name()
+	pm.nameIndex[name] = slices.DeleteFunc(pm.nameIndex[name], func(k string) bool { return k == key })
+	if len(pm.nameIndex[name]) == 0 {
+		delete(pm.nameIndex, name)
+	}
+}
+
+// beginDispatch 标记钩子调度开始，返回未禁用插件的快照
+func (pm *PluginManager) beginDispatch() []Plugin {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.dispatch++
+	plugins := make([]Plugin, 0, len(pm.plugins))
+	for _, p := range pm.plugins {
+		if _, off := pm.disabled[pluginKey(p)]; !off {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins
+}
+
+// endDispatch 标记钩子调度结束
+func (pm *PluginManager) endDispatch() {
+	pm.mu.Lock()
+	pm.dispatch--
+	pm.mu.Unlock()
+}
+
 // onBeforeMount 触发所有实现 BeforeMountHook 的插件
 func (pm *PluginManager) onBeforeMount() {
-	pm.mu.RLock()
-	plugins := append([]Plugin(nil), pm.plugins...)
-	pm.mu.RUnlock()
+	plugins := pm.beginDispatch()
+	defer pm.endDispatch()
 	mode := strings.ToLower(pm.engine.Config().GetString("plugins.hook_failure_mode"))
 	if mode == "" {
 		mode = "warn"
 	}
 	for _, p := range plugins {
 		if hook, ok := p.(BeforeMountHook); ok {
-			t := reflect.TypeOf(p)
-			key := t.PkgPath() + "." + t.Name()
+			key := pluginKey(p)
 			display := pm.ResolveDisplayName(p)
 			start := time.Now()
+			var hookErr error
 			func() {
+				defer func() { pm.recordHook(key, "before_mount", hookErr, time.Since(start)) }()
 				defer func() {
 					if r := recover(); r != nil {
+						if hookErr == nil {
+							hookErr = fmt.Errorf("panic: %v", r)
+						}
 						pm.engine.Logger().Error("插件 BeforeMount 发生 panic", "display", display, "unique_key", key, "panic", r)
 						if mode == "error" {
 							panic(fmt.Errorf("plugin panic in BeforeMount: %v", r))
@@ -253,6 +476,7 @@ func (pm *PluginManager) onBeforeMount() {
 					}
 				}()
 				if err := hook.OnBeforeMount(pm.engine); err != nil {
+					hookErr = err
 					if mode == "error" {
 						pm.engine.Logger().Error("插件 BeforeMount 执行失败", "display", display, "unique_key", key, "error", err)
 						panic(fmt.Errorf("plugin BeforeMount failed: %v", err))
@@ -268,22 +492,25 @@ func (pm *PluginManager) onBeforeMount() {
 
 // onAfterMount 触发所有实现 AfterMountHook 的插件
 func (pm *PluginManager) onAfterMount() {
-	pm.mu.RLock()
-	plugins := append([]Plugin(nil), pm.plugins...)
-	pm.mu.RUnlock()
+	plugins := pm.beginDispatch()
+	defer pm.endDispatch()
 	mode := strings.ToLower(pm.engine.Config().GetString("plugins.hook_failure_mode"))
 	if mode == "" {
 		mode = "warn"
 	}
 	for _, p := range plugins {
 		if hook, ok := p.(AfterMountHook); ok {
-			t := reflect.TypeOf(p)
-			key := t.PkgPath() + "." + t.Name()
+			key := pluginKey(p)
 			display := pm.ResolveDisplayName(p)
 			start := time.Now()
+			var hookErr error
 			func() {
+				defer func() { pm.recordHook(key, "after_mount", hookErr, time.Since(start)) }()
 				defer func() {
 					if r := recover(); r != nil {
+						if hookErr == nil {
+							hookErr = fmt.Errorf("panic: %v", r)
+						}
 						pm.engine.Logger().Error("插件 AfterMount 发生 panic", "display", display, "unique_key", key, "panic", r)
 						if mode == "error" {
 							panic(fmt.Errorf("plugin panic in AfterMount: %v", r))
@@ -291,6 +518,7 @@ func (pm *PluginManager) onAfterMount() {
 					}
 				}()
 				if err := hook.OnAfterMount(pm.engine); err != nil {
+					hookErr = err
 					if mode == "error" {
 						pm.engine.Logger().Error("插件 AfterMount 执行失败", "display", display, "unique_key", key, "error", err)
 						panic(fmt.Errorf("plugin AfterMount failed: %v", err))
@@ -306,22 +534,25 @@ func (pm *PluginManager) onAfterMount() {
 
 // onBeforeServerStart 触发所有实现 BeforeServerStartHook 的插件
 func (pm *PluginManager) onBeforeServerStart() {
-	pm.mu.RLock()
-	plugins := append([]Plugin(nil), pm.plugins...)
-	pm.mu.RUnlock()
+	plugins := pm.beginDispatch()
+	defer pm.endDispatch()
 	mode := strings.ToLower(pm.engine.Config().GetString("plugins.hook_failure_mode"))
 	if mode == "" {
 		mode = "warn"
 	}
 	for _, p := range plugins {
 		if hook, ok := p.(BeforeServerStartHook); ok {
-			t := reflect.TypeOf(p)
-			key := t.PkgPath() + "." + t.Name()
+			key := pluginKey(p)
 			display := pm.ResolveDisplayName(p)
 			start := time.Now()
+			var hookErr error
 			func() {
+				defer func() { pm.recordHook(key, "before_server_start", hookErr, time.Since(start)) }()
 				defer func() {
 					if r := recover(); r != nil {
+						if hookErr == nil {
+							hookErr = fmt.Errorf("panic: %v", r)
+						}
 						pm.engine.Logger().Error("插件 BeforeServerStart 发生 panic", "display", display, "unique_key", key, "panic", r)
 						if mode == "error" {
 							panic(fmt.Errorf("plugin panic in BeforeServerStart: %v", r))
@@ -329,6 +560,7 @@ func (pm *PluginManager) onBeforeServerStart() {
 					}
 				}()
 				if err := hook.OnBeforeServerStart(pm.engine); err != nil {
+					hookErr = err
 					if mode == "error" {
 						pm.engine.Logger().Error("插件 BeforeServerStart 执行失败", "display", display, "unique_key", key, "error", err)
 						panic(fmt.Errorf("plugin BeforeServerStart failed: %v", err))
@@ -344,27 +576,31 @@ func (pm *PluginManager) onBeforeServerStart() {
 
 // onShutdown 触发所有实现 ShutdownHook 的插件
 func (pm *PluginManager) onShutdown() {
-	pm.mu.RLock()
-	plugins := append([]Plugin(nil), pm.plugins...)
-	pm.mu.RUnlock()
+	plugins := pm.beginDispatch()
+	defer pm.endDispatch()
 	mode := strings.ToLower(pm.engine.Config().GetString("plugins.hook_failure_mode"))
 	if mode == "" {
 		mode = "warn"
 	}
 	for _, p := range plugins {
 		if hook, ok := p.(ShutdownHook); ok {
-			t := reflect.TypeOf(p)
-			key := t.PkgPath() + "." + t.Name()
+			key := pluginKey(p)
 			display := pm.ResolveDisplayName(p)
 			start := time.Now()
+			var hookErr error
 			func() {
+				defer func() { pm.recordHook(key, "shutdown", hookErr, time.Since(start)) }()
 				defer func() {
 					if r := recover(); r != nil {
+						if hookErr == nil {
+							hookErr = fmt.Errorf("panic: %v", r)
+						}
 						pm.engine.Logger().Error("插件 Shutdown 发生 panic", "display", display, "unique_key", key, "panic", r)
 						// 关闭阶段不阻断
 					}
 				}()
 				if err := hook.OnShutdown(pm.engine); err != nil {
+					hookErr = err
 					// 关闭阶段不阻断
 					pm.engine.Logger().Error("插件 Shutdown 执行失败", "display", display, "unique_key", key, "error", err)
 				}
@@ -374,6 +610,57 @@ func (pm *PluginManager) onShutdown() {
 	}
 }
 
+// Rollback 按初始化逆序调用已初始化插件的 UnloadHook，用于启动阶段致命失败后的资源回收
+// 引擎在插件 Init 失败（Register / RegisterAll）、启动钩子（error 模式）或 HTTP 服务器启动失败时会自动调用；
+// 若应用将 Register 返回的其他错误（如重复注册、版本不兼容）视为致命错误，应在退出前手动调用。
+// 回滚的插件从插件列表与全部索引中移除，之后不再参与任何钩子调度（包括 Shutdown）；
+// 没有已注册插件时调用无效果，回滚后重新注册的插件在再次失败时同样会被回滚。
+func (pm *PluginManager) Rollback() {
+	pm.mu.Lock()
+	plugins := append([]Plugin(nil), pm.plugins...)
+	displays := make([]string, len(plugins))
+	for i, p := range plugins {
+		key := pluginKey(p)
+		displays[i] = pm.resolveDisplayNameLocked(key)
+		pm.removeLocked(p, key)
+	}
+	pm.mu.Unlock()
+	if len(plugins) == 0 {
+		return
+	}
+
+	pm.engine.Logger().Warn("启动失败，开始回滚已初始化插件", "count", len(plugins))
+	for i := len(plugins) - 1; i >= 0; i-- {
+		p := plugins[i]
+		hook, ok := p.(UnloadHook)
+		if !ok {
+			continue
+		}
+		key := pluginKey(p)
+		display := displays[i]
+		start := time.Now()
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					pm.engine.Logger().Error("插件 Unload 发生 panic", "display", display, "unique_key", key, "panic", r)
+					// 回滚阶段不阻断
+				}
+			}()
+			if err := hook.OnUnload(pm.engine); err != nil {
+				// 回滚阶段不阻断
+				pm.engine.Logger().Error("插件 Unload 执行失败", "display", display, "unique_key", key, "error", err)
+			}
+		}()
+		pm.engine.Logger().Info("插件回滚完成", "phase", "unload", "order", len(plugins)-i, "display", display, "unique_key", key, "duration", time.Since(start))
+	}
+	pm.engine.Logger().Warn("插件回滚结束")
+}
+
+// errPluginNotFound 构造插件不存在错误
+func errPluginNotFound(key string) error {
+	return errors.New("plugin not found: " + key)
+}
+
 // errDuplicatePlugin 构造重复插件错误
 func errDuplicatePlugin(name string) error {
 	return errors.New("duplicate plugin: " + name)
@@ -383,6 +670,11 @@ func errDuplicatePlugin(name string) error {
 func (pm *PluginManager) resolveDisplayNameByKey(key string) string {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
+	return pm.resolveDisplayNameLocked(key)
+}
+
+// resolveDisplayNameLocked 同 resolveDisplayNameByKey（调用方需持有锁）
+func (pm *PluginManager) resolveDisplayNameLocked(key string) string {
 	if a, ok := pm.alias[key]; ok && a != "" {
 		return a
 	}
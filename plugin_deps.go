@@ -0,0 +1,178 @@
+package abe
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PluginDependencies 可选：声明插件依赖，返回依赖插件的名称、别名或唯一键（包路径 + 类型名）
+// 依赖插件须先完成注册（Init）；使用 RegisterAll 批量注册时按依赖关系自动排序
+type PluginDependencies interface {
+	DependsOn() []string
+}
+
+// pluginKey 插件唯一键（包路径 + 类型名），指针类型取其元素类型
+func pluginKey(p Plugin) string {
+	t := reflect.TypeOf(p)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// pluginDependsOn 返回插件声明的依赖（去除空白项）
+func pluginDependsOn(p Plugin) []string {
+	d, ok := p.(PluginDependencies)
+	if !ok {
+		return nil
+	}
+	var deps []string
+	for _, dep := range d.DependsOn() {
+		if dep = strings.TrimSpace(dep); dep != "" {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// registered 判断依赖引用（唯一键、别名或名称）是否已注册
+func (pm *PluginManager) registered(ref string) bool {
+	if _, ok := pm.LookupByKey(ref); ok {
+		return true
+	}
+	_, ok := pm.LookupByAliasOrName(ref)
+	return ok
+}
+
+// checkDependencies 校验插件声明的依赖均已注册
+func (pm *PluginManager) checkDependencies(p Plugin, key string) error {
+	for _, dep := range pluginDependsOn(p) {
+		if !pm.registered(dep) {
+			pm.engine.Logger().Error("插件依赖未注册，拒绝注册", "name", p.Name(), "unique_key", key, "depends_on", dep)
+			return fmt.Errorf("plugin %s depends on %s which is not registered", p.Name(), dep)
+		}
+	}
+	return nil
+}
+
+// RegisterAll 批量注册插件，按 PluginDependencies 声明的依赖关系拓扑排序后依次 Register（Init）
+// 互不依赖的插件保持传入顺序；依赖既不在本批次也未注册、或存在循环依赖时返回错误且不注册任何插件；
+// 排序后某个插件注册失败时立即返回：因 Init 返回错误失败时按逆序回滚已初始化的插件（见 Rollback），其他错误时已注册的插件保持注册状态
+//
+// 使用示例:
+//
+//	err := engine.Plugins().RegisterAll(&CachePlugin{}, &AuditPlugin{}) // AuditPlugin.DependsOn() 返回 {"cache"}
+func (pm *PluginManager) RegisterAll(plugins ...Plugin) error {
+	batch := make([]Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		if p != nil {
+			batch = append(batch, p)
+		}
+	}
+
+	// 本批次内的引用索引：唯一键与名称均可作为依赖引用
+	refs := make(map[string]int, len(batch)*2)
+	for i, p := range batch {
+		refs[pluginKey(p)] = i
+		if _, dup := refs[p.Name()]; !dup {
+			refs[p.Name()] = i
+		}
+	}
+
+	// 构建依赖图：edges[i] 为依赖 i 的插件
+	edges := make([][]int, len(batch))
+	indegree := make([]int, len(batch))
+	for i, p := range batch {
+		for _, dep := range pluginDependsOn(p) {
+			if j, ok := refs[dep]; ok && j != i {
+				edges[j] = append(edges[j], i)
+				indegree[i]++
+				continue
+			} else if ok {
+				return fmt.Errorf("plugin %s depends on itself", p.Name())
+			}
+			if !pm.registered(dep) {
+				return fmt.Errorf("plugin %s depends on %s which is not registered", p.Name(), dep)
+			}
+		}
+	}
+
+	// 拓扑排序：每轮选取入度为 0 且传入顺序最靠前的插件
+	order := make([]int, 0, len(batch))
+	done := make([]bool, len(batch))
+	for len(order) < len(batch) {
+		next := -1
+		for i := range batch {
+			if !done[i] && indegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return fmt.Errorf("plugin dependency cycle: %s", pluginCycle(batch, refs, done))
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, i := range edges[next] {
+			indegree[i]--
+		}
+	}
+
+	for _, i := range order {
+		if err := pm.Register(batch[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluginCycle 在未能排序的插件中查找一条依赖环，返回形如 "a -> b -> a" 的描述
+func pluginCycle(batch []Plugin, refs map[string]int, done []bool) string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(batch))
+	var stack []int
+	var cycle []int
+
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		state[i] = visiting
+		stack = append(stack, i)
+		for _, dep := range pluginDependsOn(batch[i]) {
+			j, ok := refs[dep]
+			if !ok || done[j] {
+				continue
+			}
+			if state[j] == visiting {
+				for k := len(stack) - 1; k >= 0; k-- {
+					if stack[k] == j {
+						cycle = append(append([]int(nil), stack[k:]...), j)
+						break
+					}
+				}
+				return true
+			}
+			if state[j] == unvisited && visit(j) {
+				return true
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		return false
+	}
+
+	for i := range batch {
+		if !done[i] && state[i] == unvisited && visit(i) {
+			break
+		}
+	}
+	names := make([]string, 0, len(cycle))
+	for _, i := range cycle {
+		names = append(names, batch[i].Name())
+	}
+	return strings.Join(names, " -> ")
+}
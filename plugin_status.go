@@ -0,0 +1,108 @@
+package abe
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PluginHealth 可选：插件健康检查，由 PluginManager.Status 按需调用
+type PluginHealth interface {
+	Health() error
+}
+
+// PluginHookResult 插件最近一次执行某个生命周期阶段的结果
+type PluginHookResult struct {
+	OK       bool          `json:"ok"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	At       time.Time     `json:"at"`
+}
+
+// PluginStatus 插件运行状态
+type PluginStatus struct {
+	Key     string                      `json:"key"`             // 唯一键（包路径 + 类型名）
+	Name    string                      `json:"name"`            // 插件名称
+	Alias   string                      `json:"alias,omitempty"` // 别名（名称冲突或配置指定时）
+	Version string                      `json:"version"`         // 插件版本
+	Enabled bool                        `json:"enabled"`         // 是否未被运行时禁用
+	Hooks   map[string]PluginHookResult `json:"hooks"`           // 阶段（init、before_mount 等）-> 最近一次结果
+	Healthy *bool                       `json:"healthy,omitempty"`
+	Health  string                      `json:"health,omitempty"` // 健康检查失败原因；未实现 PluginHealth 时 Healthy 为空
+}
+
+// recordHook 记录插件某个阶段的执行结果
+func (pm *PluginManager) recordHook(key, phase string, err error, d time.Duration) {
+	res := PluginHookResult{OK: err == nil, Duration: d, At: time.Now()}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if pm.hookResults[key] == nil {
+		pm.hookResults[key] = make(map[string]PluginHookResult)
+	}
+	pm.hookResults[key][phase] = res
+}
+
+// Status 返回全部已注册插件的状态（按注册顺序），实现 PluginHealth 的插件会同步执行一次健康检查
+func (pm *PluginManager) Status() []PluginStatus {
+	pm.mu.RLock()
+	plugins := append([]Plugin(nil), pm.plugins...)
+	statuses := make([]PluginStatus, 0, len(plugins))
+	for _, p := range plugins {
+		key := pluginKey(p)
+		_, disabled := pm.disabled[key]
+		hooks := make(map[string]PluginHookResult, len(pm.hookResults[key]))
+		for phase, res := range pm.hookResults[key] {
+			hooks[phase] = res
+		}
+		statuses = append(statuses, PluginStatus{
+			Key:     key,
+			Name:    p.Name(),
+			Alias:   pm.alias[key],
+			Version: p.Version(),
+			Enabled: !disabled,
+			Hooks:   hooks,
+		})
+	}
+	pm.mu.RUnlock()
+
+	// 健康检查在锁外执行，允许插件在 Health 中访问插件管理器
+	for i, p := range plugins {
+		h, ok := p.(PluginHealth)
+		if !ok {
+			continue
+		}
+		err := pluginHealth(h)
+		healthy := err == nil
+		statuses[i].Healthy = &healthy
+		if err != nil {
+			statuses[i].Health = err.Error()
+		}
+	}
+	return statuses
+}
+
+// pluginHealth 调用插件健康检查，将 panic 转换为错误
+func pluginHealth(h PluginHealth) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h.Health()
+}
+
+// PluginStatusHandler 插件状态查询处理器，以 JSON 返回 PluginManager.Status
+//
+// 使用示例:
+//
+//	admin.GET("/plugins", abe.PluginStatusHandler(e))
+func PluginStatusHandler(e *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, Response[[]PluginStatus]{Msg: "ok", Data: e.Plugins().Status()})
+	}
+}
@@ -0,0 +1,144 @@
+package abe
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// newPluginTestEngine 构造仅含配置与日志的最小引擎，供插件管理器测试使用
+func newPluginTestEngine() *Engine {
+	return &Engine{
+		config: viper.New(),
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+// listingPlugin 在 Init 中重入插件管理器读取已注册插件列表
+type listingPlugin struct {
+	seen []Plugin
+}
+
+func (p *listingPlugin) Name() string    { return "listing" }
+func (p *listingPlugin) Version() string { return "1.0.0" }
+func (p *listingPlugin) Init(engine *Engine) error {
+	p.seen = engine.Plugins().List()
+	return nil
+}
+
+// otherPlugin 另一个以指针注册的插件类型
+type otherPlugin struct{}
+
+func (p *otherPlugin) Name() string              { return "other" }
+func (p *otherPlugin) Version() string           { return "1.0.0" }
+func (p *otherPlugin) Init(engine *Engine) error { return nil }
+
+func TestPointerPluginsKeyedByElementType(t *testing.T) {
+	e := newPluginTestEngine()
+	for _, p := range []Plugin{&listingPlugin{}, &otherPlugin{}} {
+		if err := e.Plugins().Register(p); err != nil {
+			t.Fatalf("注册插件 %s 失败: %v", p.Name(), err)
+		}
+	}
+	for _, key := range []string{"github.com/otzgo/abe.listingPlugin", "github.com/otzgo/abe.otherPlugin"} {
+		if _, ok := e.Plugins().LookupByKey(key); !ok {
+			t.Fatalf("按唯一键 %s 未找到插件", key)
+		}
+	}
+}
+
+func TestRegisterAllowsReentrantInit(t *testing.T) {
+	e := newPluginTestEngine()
+	p := &listingPlugin{}
+
+	done := make(chan error, 1)
+	go func() { done <- e.Plugins().Register(p) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("注册插件失败: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Init 中调用 Plugins().List() 导致 Register 死锁")
+	}
+
+	if len(p.seen) != 0 {
+		t.Fatalf("Init 期间插件尚未登记，期望列表为空，实际 %d 个", len(p.seen))
+	}
+	if got := e.Plugins().List(); len(got) != 1 || got[0] != p {
+		t.Fatalf("注册后列表应仅包含该插件，实际 %v", got)
+	}
+}
+
+// unloadPlugin 记录回滚卸载与关闭钩子的调用次数，initErr 非空时 Init 失败
+type unloadPlugin struct {
+	name     string
+	initErr  error
+	unloaded int
+	shutdown int
+}
+
+func (p *unloadPlugin) Name() string                    { return p.name }
+func (p *unloadPlugin) Version() string                 { return "1.0.0" }
+func (p *unloadPlugin) Init(*Engine) error              { return p.initErr }
+func (p *unloadPlugin) OnUnload(engine *Engine) error   { p.unloaded++; return nil }
+func (p *unloadPlugin) OnShutdown(engine *Engine) error { p.shutdown++; return nil }
+
+// failingPlugin 与 unloadPlugin 类型不同，以获得不同的唯一键
+type failingPlugin struct{ unloadPlugin }
+
+func TestRegisterInitFailureRollsBack(t *testing.T) {
+	e := newPluginTestEngine()
+	ok := &unloadPlugin{name: "cache"}
+	bad := &failingPlugin{unloadPlugin{name: "audit", initErr: errors.New("连接失败")}}
+
+	if err := e.Plugins().RegisterAll(ok, bad); err == nil {
+		t.Fatal("Init 失败时应返回错误")
+	}
+	if ok.unloaded != 1 {
+		t.Fatalf("已初始化的插件应被回滚卸载一次，实际 %d 次", ok.unloaded)
+	}
+	if bad.unloaded != 0 {
+		t.Fatal("Init 失败的插件未完成初始化，不应被卸载")
+	}
+	if got := e.Plugins().List(); len(got) != 0 {
+		t.Fatalf("回滚后插件应从注册表移除，实际仍有 %d 个", len(got))
+	}
+	if _, found := e.Plugins().LookupByAliasOrName("cache"); found {
+		t.Fatal("回滚后不应再能按名称查找到插件")
+	}
+
+	// 已回滚的插件不再参与关闭钩子
+	e.Plugins().onShutdown()
+	if ok.shutdown != 0 {
+		t.Fatalf("已回滚的插件不应再执行 OnShutdown，实际 %d 次", ok.shutdown)
+	}
+}
+
+func TestRollbackAfterReregistration(t *testing.T) {
+	e := newPluginTestEngine()
+	first := &unloadPlugin{name: "cache"}
+	if err := e.Plugins().RegisterAll(first, &failingPlugin{unloadPlugin{name: "audit", initErr: errors.New("连接失败")}}); err == nil {
+		t.Fatal("Init 失败时应返回错误")
+	}
+
+	// 回滚后重新注册同一唯一键的插件，再次失败时仍应回滚
+	second := &unloadPlugin{name: "cache"}
+	if err := e.Plugins().Register(second); err != nil {
+		t.Fatalf("回滚后重新注册失败: %v", err)
+	}
+	if err := e.Plugins().Register(&failingPlugin{unloadPlugin{name: "audit", initErr: errors.New("连接失败")}}); err == nil {
+		t.Fatal("Init 失败时应返回错误")
+	}
+	if first.unloaded != 1 || second.unloaded != 1 {
+		t.Fatalf("每次失败应回滚当时已初始化的插件，实际 first=%d second=%d", first.unloaded, second.unloaded)
+	}
+	if got := e.Plugins().List(); len(got) != 0 {
+		t.Fatalf("回滚后插件应从注册表移除，实际仍有 %d 个", len(got))
+	}
+}
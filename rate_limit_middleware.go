@@ -0,0 +1,179 @@
+package abe
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitScope 限流维度
+type RateLimitScope string
+
+const (
+	RateLimitScopeIP     RateLimitScope = "ip"     // 按客户端 IP 独立限流
+	RateLimitScopeGlobal RateLimitScope = "global" // 所有请求共享同一令牌桶
+)
+
+// defaultRateLimitIdleTTL 按键限流时，空闲键的默认淘汰时间
+const defaultRateLimitIdleTTL = 10 * time.Minute
+
+// RateLimitOptions 限流中间件选项
+type RateLimitOptions struct {
+	Scope   RateLimitScope                // 限流维度，默认 RateLimitScopeIP
+	Rate    float64                       // 令牌生成速率（每秒请求数），必须大于 0
+	Burst   int                           // 令牌桶容量（允许的突发请求数），<= 0 时取 max(1, ceil(Rate))
+	KeyFunc func(ctx *gin.Context) string // 自定义限流键（如按用户 ID），设置后忽略 Scope
+	IdleTTL time.Duration                 // 按键限流时空闲键的淘汰时间，默认 10 分钟
+	Clock   Clock                         // 时钟，默认 SystemClock；测试中可使用 ManualClock 精确控制令牌补充
+}
+
+// RateLimitError 限流错误
+// 包装 ErrTooManyRequests，错误处理器可通过 errors.As 获取 RetryAfter 等信息
+type RateLimitError struct {
+	Scope      RateLimitScope // 命中的限流维度
+	Key        string         // 命中的限流键（全局限流时为空）
+	Rate       float64        // 令牌生成速率
+	Burst      int            // 令牌桶容量
+	RetryAfter time.Duration  // 建议的重试等待时间
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("请求过于频繁，请在 %s 后重试", e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrTooManyRequests
+}
+
+// RateLimitMiddleware 令牌桶限流中间件
+//
+// 参数:
+//   - opts: 限流选项，Rate 必须大于 0
+//
+// 返回:
+//   - gin.HandlerFunc: Gin 中间件函数
+//
+// 行为:
+//   - 请求被拒绝时设置 Retry-After 响应头（秒，向上取整），
+//     并通过 ctx.Error 传递 *RateLimitError（包装 ErrTooManyRequests），未注册其他处理器时由 SentinelErrorHandler 渲染为 429
+//   - 按 IP 或自定义键限流时，空闲超过 IdleTTL 的键会被定期淘汰，避免内存无限增长
+//
+// 使用示例:
+//
+//	mg.RegisterShared("rate_limit", abe.RateLimitMiddleware(abe.RateLimitOptions{
+//	    Scope: abe.RateLimitScopeIP,
+//	    Rate:  10,
+//	    Burst: 20,
+//	}))
+func RateLimitMiddleware(opts RateLimitOptions) gin.HandlerFunc {
+	if opts.Rate <= 0 {
+		panic("RateLimitMiddleware: Rate 必须大于 0")
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = max(1, int(math.Ceil(opts.Rate)))
+	}
+	if opts.Scope == "" {
+		opts.Scope = RateLimitScopeIP
+	}
+	if opts.IdleTTL <= 0 {
+		opts.IdleTTL = defaultRateLimitIdleTTL
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil && opts.Scope == RateLimitScopeIP {
+		keyFunc = func(ctx *gin.Context) string { return ctx.ClientIP() }
+	}
+
+	var global *rate.Limiter
+	var keyed *keyedLimiters
+	if keyFunc == nil {
+		global = rate.NewLimiter(rate.Limit(opts.Rate), opts.Burst)
+	} else {
+		keyed = newKeyedLimiters(rate.Limit(opts.Rate), opts.Burst, opts.IdleTTL, opts.Clock)
+	}
+
+	return func(ctx *gin.Context) {
+		now := opts.Clock.Now()
+		limiter, key := global, ""
+		if keyed != nil {
+			key = keyFunc(ctx)
+			limiter = keyed.get(key, now)
+		}
+
+		if limiter.AllowN(now, 1) {
+			ctx.Next()
+			return
+		}
+
+		// 计算下一个令牌可用的等待时间，仅用于提示，不实际占用令牌
+		res := limiter.ReserveN(now, 1)
+		retryAfter := res.DelayFrom(now)
+		res.CancelAt(now)
+
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		_ = ctx.Error(&RateLimitError{
+			Scope:      opts.Scope,
+			Key:        key,
+			Rate:       opts.Rate,
+			Burst:      opts.Burst,
+			RetryAfter: retryAfter,
+		})
+		ctx.Abort()
+	}
+}
+
+// keyedLimiters 按键维护的令牌桶集合，惰性淘汰空闲键
+type keyedLimiters struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	idleTTL   time.Duration
+	lastSweep time.Time
+	entries   map[string]*limiterEntry
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newKeyedLimiters(limit rate.Limit, burst int, idleTTL time.Duration, clock Clock) *keyedLimiters {
+	return &keyedLimiters{
+		limit:     limit,
+		burst:     burst,
+		idleTTL:   idleTTL,
+		lastSweep: clock.Now(),
+		entries:   make(map[string]*limiterEntry),
+	}
+}
+
+// get 获取（必要时创建）指定键的令牌桶，并在超过淘汰周期时清理空闲键
+func (k *keyedLimiters) get(key string, now time.Time) *rate.Limiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if now.Sub(k.lastSweep) >= k.idleTTL {
+		for kk, e := range k.entries {
+			if now.Sub(e.lastSeen) >= k.idleTTL {
+				delete(k.entries, kk)
+			}
+		}
+		k.lastSweep = now
+	}
+
+	e, ok := k.entries[key]
+	if !ok {
+		e = &limiterEntry{limiter: rate.NewLimiter(k.limit, k.burst)}
+		k.entries[key] = e
+	}
+	e.lastSeen = now
+	return e.limiter
+}
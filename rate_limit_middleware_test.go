@@ -0,0 +1,44 @@
+package abe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimitMiddlewareRejectsWith429(t *testing.T) {
+	clock := NewManualClock(time.Unix(1700000000, 0))
+	r := newErrorTestRouter(RateLimitMiddleware(RateLimitOptions{
+		Scope: RateLimitScopeGlobal,
+		Rate:  0.5, // 每 2 秒补充一个令牌
+		Burst: 1,
+		Clock: clock,
+	}))
+	r.GET("/ping", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		return w
+	}
+
+	if w := serve(); w.Code != http.StatusOK {
+		t.Fatalf("首个请求状态码 = %d，期望 200", w.Code)
+	}
+
+	w := serve()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("超限请求状态码 = %d，期望 429（响应：%s）", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After = %q，期望 \"2\"", got)
+	}
+
+	clock.Advance(2 * time.Second)
+	if w := serve(); w.Code != http.StatusOK {
+		t.Fatalf("令牌补充后状态码 = %d，期望 200", w.Code)
+	}
+}
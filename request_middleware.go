@@ -16,6 +16,7 @@ const (
 type RequestMeta struct {
 	RequestID   string
 	RequestTime time.Time
+	Route       string // 匹配的路由模板，见 RouteTemplate
 }
 
 // requestIDMiddleware 生成/透传请求 ID，并写入上下文与响应头
@@ -68,9 +69,16 @@ func GetRequestTime(ctx *gin.Context) time.Time {
 	return time.Time{}
 }
 
+// RouteTemplate 返回当前请求匹配的路由模板（如 /api/users/:id），未匹配任何路由时返回空字符串
+// 与原始路径相比基数低，适合作为日志聚合与指标统计的分组维度
+func RouteTemplate(ctx *gin.Context) string {
+	return ctx.FullPath()
+}
+
 func GetRequestMeta(ctx *gin.Context) RequestMeta {
 	return RequestMeta{
 		RequestID:   GetRequestID(ctx),
 		RequestTime: GetRequestTime(ctx),
+		Route:       RouteTemplate(ctx),
 	}
 }
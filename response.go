@@ -0,0 +1,94 @@
+package abe
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseEnvelopeKey 成功响应信封配置在上下文中的键名
+const responseEnvelopeKey = "abe.response_envelope"
+
+// successEnvelope 成功响应的 code 与 msg
+type successEnvelope struct {
+	code ErrorCode
+	msg  string
+}
+
+// defaultSuccessEnvelope 未配置或路由未经过 responseEnvelopeMiddleware 时使用
+var defaultSuccessEnvelope = successEnvelope{code: 0, msg: "ok"}
+
+// responseEnvelopeMiddleware 将成功响应的 code 与 msg 写入上下文，供 OK、Created、Respond 使用
+//
+// 配置:
+//   - response.success_code: 成功响应的 code，默认 0
+//   - response.success_message: 成功响应的 msg，默认 "ok"
+func responseEnvelopeMiddleware(e *Engine) gin.HandlerFunc {
+	env := defaultSuccessEnvelope
+	if e.config.IsSet("response.success_code") {
+		env.code = ErrorCode(e.config.GetInt("response.success_code"))
+	}
+	if msg := e.config.GetString("response.success_message"); msg != "" {
+		env.msg = msg
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Set(responseEnvelopeKey, env)
+		ctx.Next()
+	}
+}
+
+// successResponse 按上下文中的信封配置构造成功响应体
+func successResponse[T any](ctx *gin.Context, data T) Response[T] {
+	env := defaultSuccessEnvelope
+	if v, ok := ctx.Get(responseEnvelopeKey); ok {
+		env = v.(successEnvelope)
+	}
+	return Response[T]{Code: env.code, Msg: env.msg, Data: data}
+}
+
+// OK 以 200 输出成功响应 {"code":0,"msg":"ok","data":...}
+func OK(ctx *gin.Context, data any) {
+	ctx.JSON(http.StatusOK, successResponse(ctx, data))
+}
+
+// Created 以 201 输出成功响应，用于创建资源
+func Created(ctx *gin.Context, data any) {
+	ctx.JSON(http.StatusCreated, successResponse(ctx, data))
+}
+
+// NoContent 输出 204 且不带响应体
+func NoContent(ctx *gin.Context) {
+	ctx.Status(http.StatusNoContent)
+	ctx.Writer.WriteHeaderNow()
+}
+
+// Respond 按处理结果输出响应：err 非空时交由错误处理中间件渲染并中止，否则以 200 输出成功响应
+//
+// 使用示例:
+//
+//	func (c *UserController) getUser(ctx *gin.Context) {
+//	    res, err := abe.Invoke[*GetUserUseCase](ctx)
+//	    abe.Respond(ctx, res, err)
+//	}
+func Respond[T any](ctx *gin.Context, res T, err error) {
+	if err != nil {
+		if !errorRecorded(ctx, err) {
+			_ = ctx.Error(err)
+		}
+		ctx.Abort()
+		return
+	}
+	ctx.JSON(http.StatusOK, successResponse(ctx, res))
+}
+
+// errorRecorded 判断错误是否已记录在上下文中（Invoke 会先行记录），避免重复追加
+// FieldErrors 等切片类型不可比较，视为未记录
+func errorRecorded(ctx *gin.Context, err error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+	return slices.ContainsFunc(ctx.Errors, func(e *gin.Error) bool { return e.Err == err })
+}
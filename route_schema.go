@@ -0,0 +1,294 @@
+package abe
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// RouteSchema 路由的请求/响应类型声明
+// 同一份声明既用于 Endpoint 的请求绑定与校验，也可供文档生成器输出 OpenAPI 片段，避免文档与校验规则脱节
+type RouteSchema struct {
+	Method   string       // HTTP 方法
+	Path     string       // 完整路由模板（含分组前缀，gin 格式如 /users/:id）
+	Request  reflect.Type // 请求类型，nil 表示无请求参数
+	Response reflect.Type // 响应数据类型（Response[T] 中的 T），nil 表示无数据
+}
+
+// Endpoint 注册声明了请求/响应类型的路由
+// 请求参数按方法绑定：GET/DELETE/HEAD 绑定查询参数（form 标签），其余方法按 Content-Type 解析请求体（同 BindBody）；
+// 路径参数绑定到 uri 标签字段。绑定后统一校验，处理结果以 Response[Resp] 输出，错误交由错误处理中间件渲染。
+// 声明同时记录到引擎，可通过 Engine.RouteSchemas / Engine.OpenAPIPaths 获取
+//
+// 参数:
+//   - handlers: 在处理器之前执行的中间件（如鉴权）
+//
+// 使用示例:
+//
+//	abe.Endpoint(e, rg, http.MethodPost, "/users", func(ctx *gin.Context, req CreateUserRequest) (*UserDTO, error) {
+//	    return uc.Handle(ctx, req)
+//	})
+func Endpoint[Req, Resp any](e *Engine, rg *gin.RouterGroup, method, relativePath string, handle func(ctx *gin.Context, req Req) (Resp, error), handlers ...gin.HandlerFunc) {
+	e.addRouteSchema(RouteSchema{
+		Method:   method,
+		Path:     joinRoutePath(rg.BasePath(), relativePath),
+		Request:  reflect.TypeFor[Req](),
+		Response: reflect.TypeFor[Resp](),
+	})
+
+	chain := make([]gin.HandlerFunc, 0, len(handlers)+1)
+	chain = append(chain, handlers...)
+	chain = append(chain, func(ctx *gin.Context) {
+		var req Req
+		if err := bindRequest(ctx, &req); err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		resp, err := handle(ctx, req)
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		ctx.JSON(http.StatusOK, successResponse(ctx, resp))
+	})
+	rg.Handle(method, relativePath, chain...)
+}
+
+// DescribeRoute 为未使用 Endpoint 注册的路由补充类型声明，仅用于文档生成
+// req、resp 传入对应类型的零值（如 CreateUserRequest{}），传 nil 表示无
+func (e *Engine) DescribeRoute(method, fullPath string, req, resp any) {
+	rs := RouteSchema{Method: method, Path: fullPath}
+	if req != nil {
+		rs.Request = reflect.TypeOf(req)
+	}
+	if resp != nil {
+		rs.Response = reflect.TypeOf(resp)
+	}
+	e.addRouteSchema(rs)
+}
+
+// RouteSchemas 返回已声明的路由类型快照
+func (e *Engine) RouteSchemas() []RouteSchema {
+	e.routeSchemasMu.RLock()
+	defer e.routeSchemasMu.RUnlock()
+	cp := make([]RouteSchema, len(e.routeSchemas))
+	copy(cp, e.routeSchemas)
+	return cp
+}
+
+func (e *Engine) addRouteSchema(rs RouteSchema) {
+	e.routeSchemasMu.Lock()
+	defer e.routeSchemasMu.Unlock()
+	e.routeSchemas = append(e.routeSchemas, rs)
+}
+
+// bindRequest 绑定查询参数或请求体，再绑定路径参数，最后统一校验
+func bindRequest(ctx *gin.Context, obj any) error {
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodDelete, http.MethodHead:
+		if err := binding.MapFormWithTag(obj, ctx.Request.URL.Query(), "form"); err != nil {
+			return fmt.Errorf("查询参数解析失败: %w: %w", err, ErrBadRequest)
+		}
+	default:
+		if err := parseBody(ctx, obj); err != nil {
+			return err
+		}
+	}
+	if len(ctx.Params) > 0 {
+		params := make(map[string][]string, len(ctx.Params))
+		for _, p := range ctx.Params {
+			params[p.Key] = []string{p.Value}
+		}
+		if err := binding.MapFormWithTag(obj, params, "uri"); err != nil {
+			return fmt.Errorf("路径参数解析失败: %w: %w", err, ErrBadRequest)
+		}
+	}
+	return validateRequest(ctx, obj)
+}
+
+// ginPathParam 匹配 gin 路由模板中的 :name 与 *name 参数
+var ginPathParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// OpenAPIPaths 将已声明的路由生成为 OpenAPI 3 的 paths 片段，可合并到 swaggo 生成的文档中
+// 请求体/查询参数与响应均由 Go 类型推导（json/form/uri 标签决定字段名，validate:"required" 决定必填），
+// 响应按框架统一结构 {code,msg,data} 包装
+func (e *Engine) OpenAPIPaths() map[string]any {
+	paths := make(map[string]any)
+	for _, rs := range e.RouteSchemas() {
+		p := ginPathParam.ReplaceAllString(rs.Path, "{$1}")
+		item, _ := paths[p].(map[string]any)
+		if item == nil {
+			item = make(map[string]any)
+			paths[p] = item
+		}
+
+		op := map[string]any{}
+		var params []any
+		for _, m := range ginPathParam.FindAllStringSubmatch(rs.Path, -1) {
+			params = append(params, map[string]any{"name": m[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"}})
+		}
+		if rs.Request != nil {
+			switch rs.Method {
+			case http.MethodGet, http.MethodDelete, http.MethodHead:
+				params = append(params, queryParameters(rs.Request)...)
+			default:
+				op["requestBody"] = map[string]any{
+					"required": true,
+					"content":  map[string]any{gin.MIMEJSON: map[string]any{"schema": jsonSchema(rs.Request, 0)}},
+				}
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		envelope := map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code": map[string]any{"type": "integer"},
+				"msg":  map[string]any{"type": "string"},
+			},
+		}
+		if rs.Response != nil {
+			envelope["properties"].(map[string]any)["data"] = jsonSchema(rs.Response, 0)
+		}
+		op["responses"] = map[string]any{
+			"200": map[string]any{
+				"description": "OK",
+				"content":     map[string]any{gin.MIMEJSON: map[string]any{"schema": envelope}},
+			},
+		}
+		item[strings.ToLower(rs.Method)] = op
+	}
+	return paths
+}
+
+// queryParameters 将结构体的 form 字段展开为查询参数
+func queryParameters(t reflect.Type) []any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var params []any
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := tagName(f, "form")
+		if !f.IsExported() || name == "-" || name == "" {
+			continue
+		}
+		params = append(params, map[string]any{
+			"name":     name,
+			"in":       "query",
+			"required": isRequiredField(f),
+			"schema":   jsonSchema(f.Type, 1),
+		})
+	}
+	return params
+}
+
+// maxSchemaDepth 推导 JSON Schema 的最大嵌套深度，防止自引用类型无限递归
+const maxSchemaDepth = 8
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// jsonSchema 由 Go 类型推导 JSON Schema
+func jsonSchema(t reflect.Type, depth int) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		if depth >= maxSchemaDepth {
+			return map[string]any{"type": "array"}
+		}
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem(), depth+1)}
+	case reflect.Map:
+		if depth >= maxSchemaDepth {
+			return map[string]any{"type": "object"}
+		}
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem(), depth+1)}
+	case reflect.Struct:
+		if depth >= maxSchemaDepth {
+			return map[string]any{"type": "object"}
+		}
+		props := map[string]any{}
+		var required []string
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := tagName(f, "json")
+			if name == "-" {
+				continue
+			}
+			// 匿名嵌入且未指定 json 名称的结构体字段平铺到当前层级
+			if f.Anonymous && f.Tag.Get("json") == "" {
+				if sub := jsonSchema(f.Type, depth+1); sub["properties"] != nil {
+					for k, v := range sub["properties"].(map[string]any) {
+						props[k] = v
+					}
+					if req, ok := sub["required"].([]string); ok {
+						required = append(required, req...)
+					}
+				}
+				continue
+			}
+			props[name] = jsonSchema(f.Type, depth+1)
+			if isRequiredField(f) {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
+
+// tagName 读取字段指定标签的名称部分，未设置时使用字段名
+func tagName(f reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// isRequiredField 字段校验规则是否包含 required
+func isRequiredField(f reflect.StructField) bool {
+	for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
@@ -70,9 +70,14 @@ type UseCase[T any] interface {
 }
 
 var (
-	ErrUnauthorized   = errors.New("unauthorized")          // 未认证
-	ErrForbidden      = errors.New("forbidden")             // 无权限
-	ErrInternalServer = errors.New("internal server error") // 内部错误
+	ErrBadRequest       = errors.New("bad request")           // 请求不合法
+	ErrUnauthorized     = errors.New("unauthorized")          // 未认证
+	ErrForbidden        = errors.New("forbidden")             // 无权限
+	ErrInternalServer   = errors.New("internal server error") // 内部错误
+	ErrTooManyRequests  = errors.New("too many requests")     // 请求过于频繁
+	ErrGatewayTimeout   = errors.New("gateway timeout")       // 请求处理超时
+	ErrURITooLong       = errors.New("uri too long")          // 请求 URI 过长
+	ErrResourceNotFound = errors.New("resource not found")    // 请求的资源不存在
 )
 
 // ErrorCode 业务错误码
@@ -0,0 +1,116 @@
+package abe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SSEOption SSEHandler 选项
+type SSEOption func(*sseOptions)
+
+type sseOptions struct {
+	heartbeat time.Duration
+}
+
+// WithSSEHeartbeat 按间隔发送心跳注释帧（": ping"），避免代理或负载均衡因连接空闲而断开
+// interval <= 0 表示不发送心跳（默认）
+func WithSSEHeartbeat(interval time.Duration) SSEOption {
+	return func(o *sseOptions) {
+		o.heartbeat = interval
+	}
+}
+
+// SSEHandler 将事件总线主题以 Server-Sent Events 推送给客户端
+// 每个请求独立订阅主题，连接期间收到的事件按载荷编码（元数据 abe_content_type，默认 JSON）解码为 T，
+// 再以 JSON 写出为一帧：id 为消息 UUID，event 为主题名；解码失败的消息会被跳过
+// 客户端断开（请求上下文结束）后取消订阅；流式响应不受 server.write_timeout 限制
+//
+// 使用示例:
+//
+//	rg.GET("/notifications/stream", abe.SSEHandler[Notification](e.EventBus(), "notification.created",
+//	    abe.WithSSEHeartbeat(15*time.Second)))
+func SSEHandler[T any](bus EventBus, topic string, opts ...SSEOption) gin.HandlerFunc {
+	var o sseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(ctx *gin.Context) {
+		subCtx, cancel := context.WithCancel(ctx.Request.Context())
+		defer cancel()
+
+		ch, err := bus.Subscribe(subCtx, topic)
+		if err != nil {
+			_ = ctx.Error(fmt.Errorf("订阅事件主题 %s 失败：%w", topic, ErrInternalServer))
+			ctx.Abort()
+			return
+		}
+		// 总线在上一条消息确认前不会投递下一条，断开后继续确认剩余消息直到通道关闭，避免转发协程阻塞
+		defer func() {
+			go func() {
+				for msg := range ch {
+					msg.Ack()
+				}
+			}()
+		}()
+
+		_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+		header := ctx.Writer.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		header.Set("X-Accel-Buffering", "no")
+		ctx.Status(http.StatusOK)
+		ctx.Writer.Flush()
+
+		var heartbeat <-chan time.Time
+		if o.heartbeat > 0 {
+			ticker := time.NewTicker(o.heartbeat)
+			defer ticker.Stop()
+			heartbeat = ticker.C
+		}
+
+		for {
+			select {
+			case <-subCtx.Done():
+				return
+			case <-heartbeat:
+				if _, err := fmt.Fprint(ctx.Writer, ": ping\n\n"); err != nil {
+					return
+				}
+				ctx.Writer.Flush()
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				data, err := encodeSSEData[T](msg)
+				msg.Ack()
+				if err != nil {
+					continue
+				}
+				if _, err := fmt.Fprintf(ctx.Writer, "id: %s\nevent: %s\ndata: %s\n\n", msg.UUID(), topic, data); err != nil {
+					return
+				}
+				ctx.Writer.Flush()
+			}
+		}
+	}
+}
+
+// encodeSSEData 按消息编码类型解码载荷为 T，并重新编码为单行 JSON
+func encodeSSEData[T any](msg *EventMessage) ([]byte, error) {
+	var codec Codec[T] = JSONCodec[T]{}
+	if msg.Metadata(EventContentTypeMetadataKey) == (MsgPackCodec[T]{}).ContentType() {
+		codec = MsgPackCodec[T]{}
+	}
+	event, err := codec.Unmarshal(msg.Payload())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(event)
+}
@@ -0,0 +1,54 @@
+package abe
+
+import (
+	"errors"
+	"runtime/debug"
+	"strings"
+)
+
+// stackError 附带调用栈的错误
+type stackError struct {
+	err   error
+	stack []byte
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+func (e *stackError) Stack() []byte {
+	return e.stack
+}
+
+// WithStack 在调用处捕获调用栈并附加到错误上；err 为 nil 或已附带调用栈时原样返回
+// 5xx 错误经错误处理中间件输出时会记录该调用栈，app.debug=true 时同时在响应中返回
+//
+// 使用示例:
+//
+//	if err := db.Create(&order).Error; err != nil {
+//	    return nil, abe.WithStack(fmt.Errorf("创建订单失败：%w", err))
+//	}
+func WithStack(err error) error {
+	if err == nil || ErrorStack(err) != nil {
+		return err
+	}
+	return &stackError{err: err, stack: debug.Stack()}
+}
+
+// ErrorStack 返回错误链中通过 WithStack 捕获的调用栈，不存在时返回 nil
+func ErrorStack(err error) []byte {
+	var se interface{ Stack() []byte }
+	if errors.As(err, &se) {
+		return se.Stack()
+	}
+	return nil
+}
+
+// stackLines 将调用栈拆分为行，便于在 JSON 响应中阅读
+func stackLines(stack []byte) []string {
+	return strings.Split(strings.TrimRight(string(stack), "\n"), "\n")
+}
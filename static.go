@@ -0,0 +1,148 @@
+package abe
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// staticMount 静态文件挂载
+type staticMount struct {
+	prefix string // 规范化后的 URL 前缀，根路径为 ""
+	fsys   fs.FS
+	index  string // SPA 入口文件，非空表示 SPA 模式
+}
+
+// ServeStatic 将目录 dir 以只读方式挂载到 URL 前缀 urlPrefix，应在 Run 之前调用
+// 目录请求返回其中的 index.html（不提供目录列表），文件不存在时返回 404
+//
+// 使用示例:
+//
+//	engine.ServeStatic("/assets", "./public")
+func (e *Engine) ServeStatic(urlPrefix, dir string) {
+	e.ServeStaticFS(urlPrefix, os.DirFS(dir))
+}
+
+// ServeStaticFS 同 ServeStatic，文件来自 fs.FS（如 embed.FS），便于单文件部署
+//
+// 使用示例:
+//
+//	//go:embed public
+//	var public embed.FS
+//
+//	sub, _ := fs.Sub(public, "public")
+//	engine.ServeStaticFS("/assets", sub)
+func (e *Engine) ServeStaticFS(urlPrefix string, fsys fs.FS) {
+	e.addStaticMount(staticMount{prefix: normalizeURLPrefix(urlPrefix), fsys: fsys})
+}
+
+// ServeSPA 以单页应用模式挂载目录 dir：存在的文件直接返回；不存在且路径无扩展名时返回 indexFile（默认 index.html），
+// 交由前端路由处理；带扩展名的资源路径（如 /app.js）不存在时仍返回 404
+//
+// 挂载通过 NoRoute 实现，已注册的 API 路由始终优先；basePath 下的路径不会回退到 indexFile，
+// 未匹配的接口请求仍返回 404
+//
+// 使用示例:
+//
+//	engine.ServeSPA("/", "./web/dist", "index.html")
+func (e *Engine) ServeSPA(urlPrefix, dir, indexFile string) {
+	e.ServeSPAFS(urlPrefix, os.DirFS(dir), indexFile)
+}
+
+// ServeSPAFS 同 ServeSPA，文件来自 fs.FS（如 embed.FS）
+//
+// 使用示例:
+//
+//	//go:embed web/dist
+//	var dist embed.FS
+//
+//	sub, _ := fs.Sub(dist, "web/dist")
+//	engine.ServeSPAFS("/", sub, "index.html")
+func (e *Engine) ServeSPAFS(urlPrefix string, fsys fs.FS, indexFile string) {
+	if indexFile == "" {
+		indexFile = "index.html"
+	}
+	e.addStaticMount(staticMount{prefix: normalizeURLPrefix(urlPrefix), fsys: fsys, index: strings.TrimPrefix(indexFile, "/")})
+}
+
+func (e *Engine) addStaticMount(m staticMount) {
+	e.staticMu.Lock()
+	defer e.staticMu.Unlock()
+	e.staticMounts = append(e.staticMounts, m)
+}
+
+// mountStatic 注册静态文件处理器（仅启动阶段，无挂载时跳过）
+// 前缀较长的挂载优先匹配；未命中任何挂载时保持默认 404
+func (e *Engine) mountStatic() {
+	e.staticMu.Lock()
+	mounts := slices.Clone(e.staticMounts)
+	e.staticMu.Unlock()
+	if len(mounts) == 0 {
+		return
+	}
+	slices.SortStableFunc(mounts, func(a, b staticMount) int {
+		return len(b.prefix) - len(a.prefix)
+	})
+	apiPrefix := normalizeURLPrefix(e.basePath)
+
+	e.router.NoRoute(func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			return
+		}
+		reqPath := ctx.Request.URL.Path
+		for _, m := range mounts {
+			if !hasURLPrefix(reqPath, m.prefix) {
+				continue
+			}
+			// 文件名经 path.Clean 规范化，fs.FS 本身也拒绝 ".." 路径
+			name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(reqPath, m.prefix)), "/")
+			if name == "" {
+				name = "."
+			}
+			if file, ok := resolveStaticFile(m.fsys, name); ok {
+				http.ServeFileFS(ctx.Writer, ctx.Request, m.fsys, file)
+				return
+			}
+			if m.index == "" || path.Ext(name) != "" {
+				return
+			}
+			// 接口前缀下的未匹配路径不回退到入口文件，除非 SPA 本身挂载在接口前缀之下
+			if apiPrefix != "" && hasURLPrefix(reqPath, apiPrefix) && !hasURLPrefix(m.prefix, apiPrefix) {
+				return
+			}
+			http.ServeFileFS(ctx.Writer, ctx.Request, m.fsys, m.index)
+			return
+		}
+	})
+}
+
+// resolveStaticFile 返回可直接输出的文件名：普通文件原样返回，目录返回其中的 index.html
+func resolveStaticFile(fsys fs.FS, name string) (string, bool) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return "", false
+	}
+	if !info.IsDir() {
+		return name, true
+	}
+	index := path.Join(name, "index.html")
+	if info, err := fs.Stat(fsys, index); err == nil && !info.IsDir() {
+		return index, true
+	}
+	return "", false
+}
+
+// normalizeURLPrefix 规范化 URL 前缀：保证以 / 开头、去掉末尾 /，根路径返回 ""
+func normalizeURLPrefix(prefix string) string {
+	return strings.TrimRight(path.Clean("/"+prefix), "/")
+}
+
+// hasURLPrefix 判断路径是否位于前缀之下（按路径段匹配，/app 不匹配 /apple）
+func hasURLPrefix(p, prefix string) bool {
+	return prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
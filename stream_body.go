@@ -0,0 +1,89 @@
+package abe
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultStreamChunkSize StreamBody 默认的单次读取块大小
+const defaultStreamChunkSize = 32 * 1024
+
+// IsStreamingRequest 判断请求是否为流式上传
+//
+// 满足任一条件即视为流式上传：
+//   - Transfer-Encoding 为 chunked（长度未知）
+//   - threshold > 0 且 Content-Length 超过 threshold
+//
+// 中间件可据此跳过对请求体的缓冲（如日志记录），仅做流式计数与限制。
+func IsStreamingRequest(r *http.Request, threshold int64) bool {
+	if r == nil || r.Body == nil || r.Body == http.NoBody {
+		return false
+	}
+	if slices.ContainsFunc(r.TransferEncoding, func(te string) bool {
+		return strings.EqualFold(te, "chunked")
+	}) {
+		return true
+	}
+	return threshold > 0 && r.ContentLength > threshold
+}
+
+// StreamBody 以固定大小的块增量读取请求体，不将其整体载入内存
+//
+// 开始读取前清除连接的读写截止时间，上传耗时不受 server.read_timeout 与 server.write_timeout 限制；
+// 需要限制上传时长时应由处理器自行控制（如 TimeoutMiddleware 或 ctx.Request.Context()）。
+//
+// 参数:
+//   - ctx: 当前请求上下文
+//   - chunkSize: 单次读取的块大小，<= 0 时使用 32KB
+//   - fn: 块处理函数，chunk 在下一次回调前有效，需保留时应自行拷贝；返回错误将中止读取
+//
+// 返回:
+//   - int64: 已读取的总字节数
+//   - error: 读取或处理过程中的错误；请求体超过 BodyLimitMiddleware 限制时为 *BodyTooLargeError
+//
+// 使用示例:
+//
+//	n, err := abe.StreamBody(ctx, 0, func(chunk []byte) error {
+//	    _, err := file.Write(chunk)
+//	    return err
+//	})
+//	if err != nil {
+//	    _ = ctx.Error(err)
+//	    return
+//	}
+func StreamBody(ctx *gin.Context, chunkSize int, fn func(chunk []byte) error) (int64, error) {
+	body := ctx.Request.Body
+	if body == nil || body == http.NoBody {
+		return 0, nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+	rc := http.NewResponseController(ctx.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	buf := make([]byte, chunkSize)
+	var total int64
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			total += int64(n)
+			if ferr := fn(buf[:n]); ferr != nil {
+				return total, ferr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
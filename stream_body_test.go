@@ -0,0 +1,52 @@
+package abe
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStreamBodyOutlivesServerTimeouts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/upload", func(ctx *gin.Context) {
+		n, err := StreamBody(ctx, 0, func([]byte) error { return nil })
+		if err != nil {
+			ctx.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		ctx.String(http.StatusOK, strconv.FormatInt(n, 10))
+	})
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.ReadTimeout = 100 * time.Millisecond
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	// 分块慢速上传，总耗时超过服务器的读写超时
+	pr, pw := io.Pipe()
+	go func() {
+		for range 5 {
+			time.Sleep(60 * time.Millisecond)
+			if _, err := pw.Write([]byte("chunk")); err != nil {
+				return
+			}
+		}
+		_ = pw.Close()
+	}()
+
+	resp, err := http.Post(srv.URL+"/upload", "application/octet-stream", pr)
+	if err != nil {
+		t.Fatalf("上传失败：%v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "25" {
+		t.Fatalf("状态码 = %d，响应 = %q，期望 200 与 25", resp.StatusCode, body)
+	}
+}
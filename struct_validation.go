@@ -0,0 +1,109 @@
+package abe
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterStructRule 为结构体类型注册结构体级验证规则，用于跨字段校验
+// 同一类型可多次注册，规则按注册顺序依次执行；typ 传结构体零值或指针均可
+// 规则内通过 sl.ReportError 上报的错误与字段规则一样以 validator.ValidationErrors 返回，
+// 经 BindingFieldErrors 转换为 FieldErrors，字段名与翻译遵循相同规则
+//
+// 使用示例（确认密码）:
+//
+//	type SignupRequest struct {
+//	    Password        string `json:"password" validate:"required,strong_password" label:"密码"`
+//	    ConfirmPassword string `json:"confirm_password" label:"确认密码"`
+//	}
+//
+//	engine.Validator().RegisterStructRule(SignupRequest{}, abe.FieldsEqual("ConfirmPassword", "Password"))
+//	// 不一致时：{"field":"确认密码","message":"确认密码必须等于密码","tag":"eqfield"}
+func (v *Validator) RegisterStructRule(typ any, fn validator.StructLevelFunc) {
+	t := reflect.TypeOf(typ)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("abe: RegisterStructRule 需要结构体类型，实际为 %T", typ))
+	}
+
+	v.mu.Lock()
+	v.structRules[t] = append(v.structRules[t], fn)
+	v.mu.Unlock()
+
+	// 底层验证器每个类型只保留一个结构体级函数，这里注册分发函数依次执行全部规则
+	v.instance.RegisterStructValidation(func(sl validator.StructLevel) {
+		v.mu.RLock()
+		rules := v.structRules[t]
+		v.mu.RUnlock()
+		for _, rule := range rules {
+			rule(sl)
+		}
+	}, reflect.New(t).Elem().Interface())
+}
+
+// RequiredWhen 构造"字段 other 等于 value 时，字段 field 必填"的结构体级规则
+// 错误以 required_if 标签上报，提示中包含触发条件，如"当类型为company时，税号为必填字段"
+// field 与 other 为结构体字段名（非 json 名）
+//
+// 使用示例:
+//
+//	engine.Validator().RegisterStructRule(InvoiceRequest{}, abe.RequiredWhen("TaxID", "Type", "company"))
+func RequiredWhen(field, other string, value any) validator.StructLevelFunc {
+	return func(sl validator.StructLevel) {
+		current := sl.Current()
+		fv, fld, ok := structField(current, field)
+		if !ok {
+			return
+		}
+		ov, ofld, ok := structField(current, other)
+		if !ok {
+			return
+		}
+		ov = reflect.Indirect(ov)
+		if !ov.IsValid() || fmt.Sprint(ov.Interface()) != fmt.Sprint(value) {
+			return
+		}
+		if !fv.IsZero() {
+			return
+		}
+		param := fmt.Sprintf("%s %v", fieldDisplayName(ofld), value)
+		sl.ReportError(fv.Interface(), fieldDisplayName(fld), fld.Name, "required_if", param)
+	}
+}
+
+// FieldsEqual 构造"字段 field 必须与字段 other 相等"的结构体级规则，常用于确认密码
+// 错误以 eqfield 标签上报，提示中的 other 使用其显示名（label > json > 字段名）
+func FieldsEqual(field, other string) validator.StructLevelFunc {
+	return func(sl validator.StructLevel) {
+		current := sl.Current()
+		fv, fld, ok := structField(current, field)
+		if !ok {
+			return
+		}
+		ov, ofld, ok := structField(current, other)
+		if !ok {
+			return
+		}
+		if reflect.DeepEqual(fv.Interface(), ov.Interface()) {
+			return
+		}
+		sl.ReportError(fv.Interface(), fieldDisplayName(fld), fld.Name, "eqfield", fieldDisplayName(ofld))
+	}
+}
+
+// structField 按字段名取结构体字段的值与定义
+func structField(v reflect.Value, name string) (reflect.Value, reflect.StructField, bool) {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.StructField{}, false
+	}
+	fld, ok := v.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, reflect.StructField{}, false
+	}
+	return v.FieldByIndex(fld.Index), fld, true
+}
@@ -0,0 +1,62 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware 请求超时中间件
+//
+// 参数:
+//   - d: 处理超时时间，必须大于 0
+//
+// 返回:
+//   - gin.HandlerFunc: Gin 中间件函数
+//
+// 行为:
+//   - 将 ctx.Request 替换为携带截止时间的上下文，超时后该上下文被取消，
+//     使用 ctx.Request.Context() 的下游调用（如 db.WithContext）会随之中止
+//   - 在当前请求 goroutine 中执行后续处理链，处理器的响应先写入缓冲区，正常完成后再一次性写出
+//   - 处理链返回时若截止时间已到达，丢弃缓冲区，通过 ctx.Error 传递包装 ErrGatewayTimeout 的错误并中止链条，
+//     未注册其他处理器时由 SentinelErrorHandler 渲染为 504
+//
+// 注意:
+//   - 本中间件不会强行中断处理器，超时响应在处理器返回后才写出；处理器应监听 ctx.Request.Context().Done()
+//     尽早返回，忽略取消信号的处理器会使客户端一直等待到其执行完毕
+//   - 不支持流式响应（Flush）与连接劫持（Hijack），此类路由不应挂载本中间件
+//
+// 使用示例:
+//
+//	mg.RegisterShared("timeout", abe.TimeoutMiddleware(5*time.Second))
+func TimeoutMiddleware(d time.Duration) gin.HandlerFunc {
+	if d <= 0 {
+		panic("TimeoutMiddleware: 超时时间必须大于 0")
+	}
+
+	return func(ctx *gin.Context) {
+		reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), d)
+		defer cancel()
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+
+		w := ctx.Writer
+		tw := newBufferedWriter(w)
+		ctx.Writer = tw
+		// 处理器 panic 时恢复原始 Writer，由恢复中间件写出错误响应，缓冲内容不会写出
+		defer func() { ctx.Writer = w }()
+
+		ctx.Next()
+
+		ctx.Writer = w
+		if errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+			tw.discard()
+			_ = ctx.Error(fmt.Errorf("请求处理超时（%s）: %w", d, ErrGatewayTimeout))
+			ctx.Abort()
+			return
+		}
+		tw.flush()
+	}
+}
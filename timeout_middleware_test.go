@@ -0,0 +1,35 @@
+package abe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	r := newErrorTestRouter(TimeoutMiddleware(20 * time.Millisecond))
+	r.GET("/fast", func(ctx *gin.Context) { ctx.String(http.StatusOK, "done") })
+	r.GET("/slow", func(ctx *gin.Context) {
+		<-ctx.Request.Context().Done()
+		ctx.String(http.StatusOK, "late")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "done" {
+		t.Fatalf("未超时请求 = %d %q，期望 200 \"done\"", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("超时请求状态码 = %d，期望 504（响应：%s）", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "late") {
+		t.Fatalf("超时后处理器的写入应被丢弃，实际响应：%s", w.Body.String())
+	}
+}
@@ -0,0 +1,164 @@
+package abe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName 框架创建 span 使用的 instrumentation 名称
+const tracerName = "github.com/otzgo/abe"
+
+// defaultTracingServiceName 未配置 tracing.service_name 与 app.name 时的服务名
+const defaultTracingServiceName = "abe"
+
+// setupTracing 按 tracing.* 配置初始化 OpenTelemetry，tracing.enabled 为 false 时跳过
+// 创建 OTLP/HTTP 导出器与批量处理的 TracerProvider，设置为全局 TracerProvider 与 W3C 传播器，
+// 并注册停机回调以在退出前刷新未导出的 span
+//
+// 配置项:
+//   - tracing.enabled：是否启用
+//   - tracing.otlp_endpoint：OTLP/HTTP 接收端 host:port，默认 localhost:4318
+//   - tracing.insecure：使用 HTTP 而非 HTTPS 连接接收端
+//   - tracing.sample_ratio：采样率（0~1），默认 1；上游已采样的请求始终继续采样
+//   - tracing.service_name：服务名，默认取 app.name
+func (e *Engine) setupTracing() {
+	cfg := e.config
+	if !cfg.GetBool("tracing.enabled") {
+		return
+	}
+
+	var opts []otlptracehttp.Option
+	if endpoint := cfg.GetString("tracing.otlp_endpoint"); endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+	}
+	if cfg.GetBool("tracing.insecure") {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		panic(fmt.Errorf("致命错误初始化链路追踪导出器：%w", err))
+	}
+
+	ratio := 1.0
+	if cfg.IsSet("tracing.sample_ratio") {
+		ratio = cfg.GetFloat64("tracing.sample_ratio")
+	}
+	serviceName := cfg.GetString("tracing.service_name")
+	if serviceName == "" {
+		serviceName = cfg.GetString("app.name")
+	}
+	if serviceName == "" {
+		serviceName = defaultTracingServiceName
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", Version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	e.OnShutdown(tp.Shutdown)
+	e.logger.Info("链路追踪已启用", "service", serviceName, "endpoint", cfg.GetString("tracing.otlp_endpoint"), "sample_ratio", ratio)
+}
+
+// TracingOptions TracingMiddleware 选项
+type TracingOptions struct {
+	TracerProvider trace.TracerProvider          // 为空时使用全局 TracerProvider（见 tracing.* 配置）
+	Propagator     propagation.TextMapPropagator // 为空时使用全局传播器
+	Skip           func(ctx *gin.Context) bool   // 返回 true 时不创建 span（如健康检查）
+}
+
+// TracingMiddleware 链路追踪中间件
+// 从请求头提取 W3C traceparent，以路由模板（ctx.FullPath()）为名创建服务端 span，
+// 并将 span 上下文写入 ctx.Request.Context()，下游调用与事件发布（PublishEventContext）可继续该链路；
+// trace_id / span_id 同时写入访问日志（见 AddLogField）与请求级日志记录器（见 LoggerFrom）
+//
+// 使用示例:
+//
+//	engine.MiddlewareManager().RegisterGlobal(abe.TracingMiddleware(abe.TracingOptions{}))
+func TracingMiddleware(opts TracingOptions) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if opts.Skip != nil && opts.Skip(ctx) {
+			ctx.Next()
+			return
+		}
+		tp := opts.TracerProvider
+		if tp == nil {
+			tp = otel.GetTracerProvider()
+		}
+		prop := opts.Propagator
+		if prop == nil {
+			prop = otel.GetTextMapPropagator()
+		}
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		parent := prop.Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		spanCtx, span := tp.Tracer(tracerName).Start(parent, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ctx.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", ctx.Request.URL.Path),
+				attribute.String("client.address", ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		if sc := span.SpanContext(); sc.IsValid() {
+			traceID, spanID := sc.TraceID().String(), sc.SpanID().String()
+			// 优先写入请求级日志记录器（访问日志同样使用它），否则仅追加到访问日志
+			if !withRequestLogger(ctx, "trace_id", traceID, "span_id", spanID) {
+				AddLogField(ctx, "trace_id", traceID)
+				AddLogField(ctx, "span_id", spanID)
+			}
+		}
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
+
+// WithTraceContext 将 ctx 中的链路上下文注入消息元数据并返回自身，订阅方处理时将延续该链路
+func (m *EventMessage) WithTraceContext(ctx context.Context) *EventMessage {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(m.msg.Metadata))
+	return m
+}
+
+// startEventSpan 从消息元数据提取链路上下文并创建消费端 span
+func startEventSpan(ctx context.Context, topic string, msg *EventMessage) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(msg.msg.Metadata))
+	return otel.Tracer(tracerName).Start(ctx, "event "+topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.destination.name", topic),
+			attribute.String("messaging.message.id", msg.UUID()),
+		),
+	)
+}
@@ -0,0 +1,70 @@
+package abe
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// TxRunner 在数据库事务中执行函数，由 Engine 实现并注册到 DI 容器
+// 用例可通过 do.MustInvoke[abe.TxRunner](injector) 获取，无需依赖 *Engine
+type TxRunner interface {
+	WithTx(ctx context.Context, fn func(tx *gorm.DB) error) error
+}
+
+var _ TxRunner = (*Engine)(nil)
+
+// WithTx 在事务中执行 fn：fn 返回 nil 时提交，返回错误或 panic 时回滚（panic 在回滚后继续抛出）
+//
+// 参数:
+//   - ctx: 事务上下文，传递给 tx 上的所有查询；开始前或 fn 返回后 ctx 已结束时回滚并返回 ctx.Err()
+//   - fn: 事务函数，应只使用参数 tx 访问数据库
+//
+// 返回:
+//   - error: fn 返回的错误原样返回；开启或提交事务失败时返回包装后的错误
+//
+// 注意:
+//   - 与 TransactionMiddleware 相互独立：WithTx 总是从 Engine.DB() 开启新事务，不会加入请求事务
+//
+// 使用示例:
+//
+//	err := e.WithTx(ctx, func(tx *gorm.DB) error {
+//	    if err := tx.Create(&order).Error; err != nil {
+//	        return err
+//	    }
+//	    return tx.Model(&stock).Update("count", gorm.Expr("count - ?", 1)).Error
+//	})
+func (e *Engine) WithTx(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tx := e.DB().WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("开启事务失败：%w", tx.Error)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		// fn 返回错误或 panic：回滚后按原样返回或继续抛出
+		if rbErr := tx.Rollback().Error; rbErr != nil {
+			e.Logger().Error("回滚事务失败", "error", rbErr)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		committed = true // 提交失败后事务已结束，无需再回滚
+		return fmt.Errorf("提交事务失败：%w", err)
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,101 @@
+package abe
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do/v2"
+	"gorm.io/gorm"
+)
+
+// txKey 请求级事务在 gin.Context 中的键名
+const txKey = "abe.tx"
+
+// TransactionMiddleware 请求级事务中间件（工作单元）
+//
+// 参数:
+//   - engine: 引擎实例，用于获取数据库连接与日志
+//
+// 返回:
+//   - gin.HandlerFunc: Gin 中间件函数
+//
+// 行为:
+//   - 请求开始时开启事务，并将事务绑定的 *gorm.DB 覆盖注册到请求级 DI 容器，
+//     通过 do.MustInvoke[*gorm.DB] 或 abe.Tx(ctx) 获取的连接均处于该事务中
+//   - 处理器的响应先写入缓冲区，事务结束后再写出，客户端不会在提交完成前收到成功响应
+//   - 处理完成后，状态码为 2xx 且 ctx.Errors 为空时提交，否则回滚并写出处理器的响应；处理器 panic 时回滚后继续抛出
+//   - 提交失败时丢弃缓冲的响应，通过 ctx.Error 传递包装 ErrInternalServer 的错误，由错误处理中间件输出 500
+//
+// 注意:
+//   - 按需挂载到写操作的路由分组，只读接口无需开启事务
+//   - 响应被完整缓冲，不支持流式响应（Flush）与连接劫持（Hijack），此类路由不应挂载本中间件
+//   - 事务在请求结束时即被提交或回滚，异步任务（协程池、事件订阅等）不得使用请求事务，
+//     应改用 Engine.DB() 并自行管理事务
+//
+// 使用示例:
+//
+//	g := rg.Group("/orders", abe.TransactionMiddleware(e))
+//	g.POST("", ctrl.Create)
+func TransactionMiddleware(engine *Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tx := engine.DB().WithContext(ctx.Request.Context()).Begin()
+		if tx.Error != nil {
+			_ = ctx.Error(fmt.Errorf("开启事务失败: %v: %w", tx.Error, ErrInternalServer))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(txKey, tx)
+		if v, ok := ctx.Get(doInjectorKey); ok {
+			do.OverrideValue(v.(do.Injector), tx)
+		}
+
+		w := ctx.Writer
+		bw := newBufferedWriter(w)
+		ctx.Writer = bw
+
+		finished := false
+		defer func() {
+			ctx.Writer = w
+			if finished {
+				return
+			}
+			// 处理器 panic：回滚后继续抛出，交由恢复中间件处理
+			if err := tx.Rollback().Error; err != nil {
+				engine.Logger().Error("回滚事务失败", "request_id", GetRequestID(ctx), "error", err)
+			}
+		}()
+
+		ctx.Next()
+		finished = true
+		ctx.Writer = w
+
+		status := bw.Status()
+		if status < 200 || status >= 300 || len(ctx.Errors) > 0 {
+			if err := tx.Rollback().Error; err != nil {
+				engine.Logger().Error("回滚事务失败", "request_id", GetRequestID(ctx), "status", status, "error", err)
+			}
+			bw.flush()
+			return
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			engine.Logger().Error("提交事务失败", "request_id", GetRequestID(ctx), "error", err)
+			bw.discard()
+			_ = ctx.Error(fmt.Errorf("提交事务失败: %v: %w", err, ErrInternalServer))
+			ctx.Abort()
+			return
+		}
+		bw.flush()
+	}
+}
+
+// Tx 返回当前请求的事务连接；未挂载 TransactionMiddleware 时返回 nil
+func Tx(ctx *gin.Context) *gorm.DB {
+	if v, ok := ctx.Get(txKey); ok {
+		if tx, ok2 := v.(*gorm.DB); ok2 {
+			return tx
+		}
+	}
+	return nil
+}
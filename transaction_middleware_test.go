@@ -0,0 +1,74 @@
+package abe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// newTxTestRouter 创建挂载恢复、错误处理与请求级事务中间件的路由
+func newTxTestRouter(t *testing.T) (*Engine, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	e := newTxTestEngine(t)
+	e.config = viper.New()
+	r := gin.New()
+	r.Use(ginRecovery(e.logger, false), errorHandlerMiddleware(e), TransactionMiddleware(e))
+	return e, r
+}
+
+func TestTransactionMiddlewareCommitFailure(t *testing.T) {
+	e, r := newTxTestRouter(t)
+	r.POST("/records", func(ctx *gin.Context) {
+		tx := Tx(ctx)
+		if err := tx.Create(&txTestRecord{Name: "lost"}).Error; err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		// 事务在中间件提交前已结束，使提交失败
+		tx.Rollback()
+		ctx.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/records", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("提交失败时状态码 = %d，期望 500", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "ok") {
+		t.Fatalf("提交失败时不应写出处理器的成功响应，实际：%s", w.Body.String())
+	}
+
+	var count int64
+	e.DB().Model(&txTestRecord{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("提交失败后不应有持久化的记录，实际 %d 条", count)
+	}
+}
+
+func TestTransactionMiddlewareCommitsBeforeResponse(t *testing.T) {
+	e, r := newTxTestRouter(t)
+	r.POST("/records", func(ctx *gin.Context) {
+		if err := Tx(ctx).Create(&txTestRecord{Name: "kept"}).Error; err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/records", nil))
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), "ok") {
+		t.Fatalf("提交成功时响应 = %d %s，期望 201", w.Code, w.Body.String())
+	}
+
+	var count int64
+	e.DB().Model(&txTestRecord{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("期望提交 1 条记录，实际 %d 条", count)
+	}
+}
@@ -0,0 +1,80 @@
+package abe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type txTestRecord struct {
+	ID   uint
+	Name string
+}
+
+// newTxTestEngine 构造使用内存 sqlite 数据库的最小引擎
+func newTxTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开 sqlite 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取连接池失败: %v", err)
+	}
+	// 内存库按连接隔离，限制为单连接保证事务内外访问同一数据库
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(&txTestRecord{}); err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	return &Engine{db: db, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	e := newTxTestEngine(t)
+	errFail := errors.New("业务失败")
+
+	err := e.WithTx(context.Background(), func(tx *gorm.DB) error {
+		if err := tx.Create(&txTestRecord{Name: "rollback"}).Error; err != nil {
+			return err
+		}
+		return errFail
+	})
+	if !errors.Is(err, errFail) {
+		t.Fatalf("期望原样返回 fn 的错误，实际 %v", err)
+	}
+
+	var count int64
+	if err := e.DB().Model(&txTestRecord{}).Count(&count).Error; err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("fn 返回错误后应回滚，实际残留 %d 条记录", count)
+	}
+}
+
+func TestWithTxCommitsOnSuccess(t *testing.T) {
+	e := newTxTestEngine(t)
+
+	err := e.WithTx(context.Background(), func(tx *gorm.DB) error {
+		return tx.Create(&txTestRecord{Name: "commit"}).Error
+	})
+	if err != nil {
+		t.Fatalf("事务执行失败: %v", err)
+	}
+
+	var count int64
+	if err := e.DB().Model(&txTestRecord{}).Count(&count).Error; err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("期望提交 1 条记录，实际 %d 条", count)
+	}
+}
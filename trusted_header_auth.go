@@ -0,0 +1,177 @@
+package abe
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+// 可信请求头认证的默认请求头
+const (
+	defaultTrustedUserHeader   = "X-User-ID"
+	defaultTrustedRolesHeader  = "X-User-Roles"
+	defaultTrustedTenantHeader = "X-Tenant-ID"
+)
+
+// TrustedHeaderClaims 由网关注入的请求头构建的用户声明，实现 TenantTokenClaims，可直接用于 AuthorizationMiddleware
+type TrustedHeaderClaims struct {
+	jwt.RegisteredClaims
+	User     string   `json:"uid"`
+	AllRoles []string `json:"roles,omitempty"`
+	Tenant   string   `json:"tenant,omitempty"`
+}
+
+func (c *TrustedHeaderClaims) UserID() string {
+	return c.User
+}
+
+func (c *TrustedHeaderClaims) Role() string {
+	if len(c.AllRoles) > 0 {
+		return c.AllRoles[0]
+	}
+	return ""
+}
+
+func (c *TrustedHeaderClaims) Roles() []string {
+	return c.AllRoles
+}
+
+func (c *TrustedHeaderClaims) TenantID() string {
+	return c.Tenant
+}
+
+// trustedHeaderConfig 可信请求头认证配置（auth.trusted_header.*）
+type trustedHeaderConfig struct {
+	proxies      []netip.Prefix
+	userHeader   string
+	rolesHeader  string
+	tenantHeader string
+}
+
+// loadTrustedHeaderConfig 读取 auth.trusted_header.* 配置
+// proxies 支持单个 IP 与 CIDR，解析失败时返回错误
+func loadTrustedHeaderConfig(cfg *viper.Viper) (trustedHeaderConfig, error) {
+	c := trustedHeaderConfig{
+		userHeader:   cfg.GetString("auth.trusted_header.user_header"),
+		rolesHeader:  cfg.GetString("auth.trusted_header.roles_header"),
+		tenantHeader: cfg.GetString("auth.trusted_header.tenant_header"),
+	}
+	if c.userHeader == "" {
+		c.userHeader = defaultTrustedUserHeader
+	}
+	if c.rolesHeader == "" {
+		c.rolesHeader = defaultTrustedRolesHeader
+	}
+	if c.tenantHeader == "" {
+		c.tenantHeader = defaultTrustedTenantHeader
+	}
+	for _, s := range getStringSlice(cfg, "auth.trusted_header.proxies", nil) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if strings.Contains(s, "/") {
+			prefix, err := netip.ParsePrefix(s)
+			if err != nil {
+				return c, fmt.Errorf("无效的 auth.trusted_header.proxies 项 %q：%w", s, err)
+			}
+			c.proxies = append(c.proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return c, fmt.Errorf("无效的 auth.trusted_header.proxies 项 %q：%w", s, err)
+		}
+		c.proxies = append(c.proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return c, nil
+}
+
+// trusted 判断直连对端地址是否属于可信代理
+// 使用 TCP 连接的对端地址（RemoteAddr），不读取 X-Forwarded-For，避免被客户端伪造
+func (c trustedHeaderConfig) trusted(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range c.proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustedHeaderAuthMiddleware 可信请求头认证中间件，用于在网关完成认证的架构
+// 请求来自 auth.trusted_header.proxies 中的代理时，从网关注入的请求头构建 TrustedHeaderClaims 并写入用户声明，
+// 后续 AuthorizationMiddleware / AuthorizationMiddlewareInDomain 无需改动即可鉴权
+//
+// 配置项（auth.trusted_header.*）：
+//   - proxies: 可信代理 IP 或 CIDR 列表，未配置时拒绝所有请求
+//   - user_header: 用户 ID 请求头，默认 X-User-ID
+//   - roles_header: 角色请求头（逗号分隔），默认 X-User-Roles
+//   - tenant_header: 租户请求头，默认 X-Tenant-ID
+//
+// 错误处理：
+//   - 非可信来源 -> ErrUnauthorized（携带身份请求头时记录告警，防止伪造）
+//   - 缺少用户 ID 请求头 -> ErrUnauthorized
+//
+// 使用示例：
+//
+//	api := router.Group("/api", abe.TrustedHeaderAuthMiddleware(engine))
+//	api.GET("/orders", abe.AuthorizationMiddleware(engine, "/orders", "read"), handler)
+//
+// 注意：
+//   - 可信代理必须覆盖或清除客户端自带的同名请求头，否则客户端可经代理伪造身份
+//   - 可信代理的判定只看直连对端地址，服务前还有其他负载均衡时需将其一并加入 proxies
+func TrustedHeaderAuthMiddleware(engine *Engine) gin.HandlerFunc {
+	c, err := loadTrustedHeaderConfig(engine.Config())
+	if err != nil {
+		panic(fmt.Errorf("致命错误初始化可信请求头认证：%w", err))
+	}
+	if len(c.proxies) == 0 {
+		engine.Logger().Warn("未配置 auth.trusted_header.proxies，可信请求头认证将拒绝所有请求")
+	}
+
+	return func(ctx *gin.Context) {
+		userID := strings.TrimSpace(ctx.GetHeader(c.userHeader))
+		if !c.trusted(ctx.Request.RemoteAddr) {
+			if userID != "" || ctx.GetHeader(c.rolesHeader) != "" {
+				engine.Logger().Warn("拒绝来自非可信来源的身份请求头", "remote_addr", ctx.Request.RemoteAddr, "path", ctx.Request.URL.Path)
+			}
+			_ = ctx.Error(fmt.Errorf("请求来源不可信: %w", ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+		if userID == "" {
+			_ = ctx.Error(fmt.Errorf("缺少身份请求头 %s: %w", c.userHeader, ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		var roles []string
+		for _, r := range strings.Split(ctx.GetHeader(c.rolesHeader), ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				roles = append(roles, r)
+			}
+		}
+		claims := &TrustedHeaderClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: userID},
+			User:             userID,
+			AllRoles:         roles,
+			Tenant:           strings.TrimSpace(ctx.GetHeader(c.tenantHeader)),
+		}
+		ctx.Set(contextKeyUserClaims, UserTokenClaims(claims))
+		ctx.Next()
+	}
+}
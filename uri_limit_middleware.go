@@ -0,0 +1,50 @@
+package abe
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// defaultMaxURILength 请求 URI（路径 + 查询字符串）的默认最大长度
+const defaultMaxURILength = 8192
+
+// maxURILength 读取 server.max_uri_length 配置
+// 未配置或为 0 时使用默认值 8192，负数表示不限制
+func maxURILength(cfg *viper.Viper) int {
+	n := cfg.GetInt("server.max_uri_length")
+	if n == 0 {
+		return defaultMaxURILength
+	}
+	return n
+}
+
+// URILengthLimitMiddleware 请求 URI 长度限制中间件
+//
+// 参数:
+//   - maxLength: 允许的最大 URI 长度（字节，含路径与查询字符串），必须大于 0
+//
+// 返回:
+//   - gin.HandlerFunc: Gin 中间件函数
+//
+// 超过限制时通过 ctx.Error 传递包装 ErrURITooLong 的错误并中止链条，未注册其他处理器时由 SentinelErrorHandler 渲染为 414。
+// 引擎默认为所有控制器路由挂载本中间件，限制由 server.max_uri_length 配置。
+func URILengthLimitMiddleware(maxLength int) gin.HandlerFunc {
+	if maxLength <= 0 {
+		panic("URILengthLimitMiddleware: maxLength 必须大于 0")
+	}
+
+	return func(ctx *gin.Context) {
+		uri := ctx.Request.RequestURI
+		if uri == "" {
+			uri = ctx.Request.URL.RequestURI()
+		}
+		if n := len(uri); n > maxLength {
+			_ = ctx.Error(fmt.Errorf("请求 URI 过长（%d 字节，上限 %d 字节）: %w", n, maxLength, ErrURITooLong))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
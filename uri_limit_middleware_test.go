@@ -0,0 +1,38 @@
+package abe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// newErrorTestRouter 创建挂载错误处理中间件的路由，未注册任何自定义错误处理器
+func newErrorTestRouter(middleware ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	e := &Engine{config: viper.New()}
+	r := gin.New()
+	r.Use(errorHandlerMiddleware(e))
+	r.Use(middleware...)
+	return r
+}
+
+func TestURILengthLimitMiddleware(t *testing.T) {
+	r := newErrorTestRouter(URILengthLimitMiddleware(32))
+	r.GET("/items", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?q=short", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("URI 未超限时状态码 = %d，期望 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?q="+strings.Repeat("a", 32), nil))
+	if w.Code != http.StatusRequestURITooLong {
+		t.Fatalf("URI 超限时状态码 = %d，期望 414", w.Code)
+	}
+}
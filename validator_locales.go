@@ -0,0 +1,88 @@
+package abe
+
+import (
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/ar"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fa"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/id"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/ko"
+	"github.com/go-playground/locales/lv"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pl"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/go-playground/locales/ru"
+	"github.com/go-playground/locales/th"
+	"github.com/go-playground/locales/tr"
+	"github.com/go-playground/locales/uk"
+	"github.com/go-playground/locales/vi"
+	"github.com/go-playground/locales/zh"
+	"github.com/go-playground/locales/zh_Hant_TW"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	artrans "github.com/go-playground/validator/v10/translations/ar"
+	detrans "github.com/go-playground/validator/v10/translations/de"
+	entrans "github.com/go-playground/validator/v10/translations/en"
+	estrans "github.com/go-playground/validator/v10/translations/es"
+	fatrans "github.com/go-playground/validator/v10/translations/fa"
+	frtrans "github.com/go-playground/validator/v10/translations/fr"
+	idtrans "github.com/go-playground/validator/v10/translations/id"
+	ittrans "github.com/go-playground/validator/v10/translations/it"
+	jatrans "github.com/go-playground/validator/v10/translations/ja"
+	kotrans "github.com/go-playground/validator/v10/translations/ko"
+	lvtrans "github.com/go-playground/validator/v10/translations/lv"
+	nltrans "github.com/go-playground/validator/v10/translations/nl"
+	pltrans "github.com/go-playground/validator/v10/translations/pl"
+	pttrans "github.com/go-playground/validator/v10/translations/pt"
+	ptbrtrans "github.com/go-playground/validator/v10/translations/pt_BR"
+	rutrans "github.com/go-playground/validator/v10/translations/ru"
+	thtrans "github.com/go-playground/validator/v10/translations/th"
+	trtrans "github.com/go-playground/validator/v10/translations/tr"
+	uktrans "github.com/go-playground/validator/v10/translations/uk"
+	vitrans "github.com/go-playground/validator/v10/translations/vi"
+	zhtrans "github.com/go-playground/validator/v10/translations/zh"
+	zhtwtrans "github.com/go-playground/validator/v10/translations/zh_tw"
+	"golang.org/x/text/language"
+)
+
+// defaultValidatorLocales 未配置 validator.locales 时构建翻译器的语言
+var defaultValidatorLocales = []string{"zh", "en"}
+
+// validatorLocale 验证器支持的语言：语言数据与 validator 内置翻译
+type validatorLocale struct {
+	tag      language.Tag
+	locale   func() locales.Translator
+	register func(v *validator.Validate, trans ut.Translator) error
+}
+
+// validatorLocales validator.locales 可配置的语言，键为配置值
+var validatorLocales = map[string]validatorLocale{
+	"ar":    {language.Arabic, ar.New, artrans.RegisterDefaultTranslations},
+	"de":    {language.German, de.New, detrans.RegisterDefaultTranslations},
+	"en":    {language.English, en.New, entrans.RegisterDefaultTranslations},
+	"es":    {language.Spanish, es.New, estrans.RegisterDefaultTranslations},
+	"fa":    {language.Persian, fa.New, fatrans.RegisterDefaultTranslations},
+	"fr":    {language.French, fr.New, frtrans.RegisterDefaultTranslations},
+	"id":    {language.Indonesian, id.New, idtrans.RegisterDefaultTranslations},
+	"it":    {language.Italian, it.New, ittrans.RegisterDefaultTranslations},
+	"ja":    {language.Japanese, ja.New, jatrans.RegisterDefaultTranslations},
+	"ko":    {language.Korean, ko.New, kotrans.RegisterDefaultTranslations},
+	"lv":    {language.Latvian, lv.New, lvtrans.RegisterDefaultTranslations},
+	"nl":    {language.Dutch, nl.New, nltrans.RegisterDefaultTranslations},
+	"pl":    {language.Polish, pl.New, pltrans.RegisterDefaultTranslations},
+	"pt":    {language.Portuguese, pt.New, pttrans.RegisterDefaultTranslations},
+	"pt_BR": {language.BrazilianPortuguese, pt_BR.New, ptbrtrans.RegisterDefaultTranslations},
+	"ru":    {language.Russian, ru.New, rutrans.RegisterDefaultTranslations},
+	"th":    {language.Thai, th.New, thtrans.RegisterDefaultTranslations},
+	"tr":    {language.Turkish, tr.New, trtrans.RegisterDefaultTranslations},
+	"uk":    {language.Ukrainian, uk.New, uktrans.RegisterDefaultTranslations},
+	"vi":    {language.Vietnamese, vi.New, vitrans.RegisterDefaultTranslations},
+	"zh":    {language.Chinese, zh.New, zhtrans.RegisterDefaultTranslations},
+	"zh_tw": {language.TraditionalChinese, zh_Hant_TW.New, zhtwtrans.RegisterDefaultTranslations},
+}
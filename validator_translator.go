@@ -1,46 +1,101 @@
 package abe
 
 import (
+	"slices"
+
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/locales/en"
-	"github.com/go-playground/locales/zh"
+	"github.com/go-playground/locales"
 	ut "github.com/go-playground/universal-translator"
-	entrans "github.com/go-playground/validator/v10/translations/en"
-	zhtrans "github.com/go-playground/validator/v10/translations/zh"
+	"golang.org/x/text/language"
 )
 
 const translatorContextKey = "abe.translator"
 
 // validationTranslatorMiddleware 注入翻译器到请求上下文
-// 从 Engine 中获取验证器和默认语言配置
+// 启动时为 validator.locales 中的每种语言（默认 zh、en）各构建一次翻译器并注册内置与自定义规则翻译，
+// 请求时按 i18nMiddleware 解析出的语言偏好选择，无法匹配时使用验证器的默认语言（validator.locale）
 func validationTranslatorMiddleware(e *Engine) gin.HandlerFunc {
-	var translator ut.Translator
-
-	// 获取验证器
 	validate := e.validator.Instance()
-	defaultLocale := e.validator.Locale()
 
-	// 初始化多语言
-	zhCn := zh.New()
-	enUs := en.New()
-	uni := ut.New(zhCn, zhCn, enUs)
+	names := getStringSlice(e.config, "validator.locales", defaultValidatorLocales)
+	defaultName := e.validator.Locale()
+	if _, ok := validatorLocales[defaultName]; !ok {
+		e.logger.Warn("不支持的验证器默认语言，回退为 zh", "locale", defaultName)
+		defaultName = "zh"
+	}
+	if !slices.Contains(names, defaultName) {
+		names = append([]string{defaultName}, names...)
+	}
+
+	// 默认语言置于首位
+	ordered := []string{defaultName}
+	for _, name := range names {
+		if name != defaultName && !slices.Contains(ordered, name) {
+			ordered = append(ordered, name)
+		}
+	}
+
+	supported := make([]locales.Translator, 0, len(ordered))
+	tags := make([]language.Tag, 0, len(ordered))
+	for _, name := range ordered {
+		loc, ok := validatorLocales[name]
+		if !ok {
+			e.logger.Warn("不支持的验证器语言，已忽略", "locale", name)
+			continue
+		}
+		supported = append(supported, loc.locale())
+		tags = append(tags, loc.tag)
+	}
+	uni := ut.New(supported[0], supported...)
 
-	if defaultLocale != "en" { // 默认 zh
-		translator, _ = uni.GetTranslator("zh")
-		_ = zhtrans.RegisterDefaultTranslations(validate, translator)
-		e.validator.registerCustomRuleTranslations(translator, "zh")
-	} else {
-		translator, _ = uni.GetTranslator("en")
-		_ = entrans.RegisterDefaultTranslations(validate, translator)
-		e.validator.registerCustomRuleTranslations(translator, "en")
+	translators := make([]ut.Translator, 0, len(supported))
+	for i, l := range supported {
+		trans, _ := uni.GetTranslator(l.Locale())
+		name := ordered[i]
+		if loc, ok := validatorLocales[name]; ok {
+			_ = loc.register(validate, trans)
+		}
+		if name == "zh" || name == "en" {
+			e.validator.registerConditionalTranslations(trans, name)
+		}
+		e.validator.attachTranslator(trans, name)
+		translators = append(translators, trans)
 	}
 
+	selector := &translatorSelector{matcher: language.NewMatcher(tags), translators: translators}
+
 	return func(ctx *gin.Context) {
-		ctx.Set(translatorContextKey, translator)
+		ctx.Set(translatorContextKey, selector.pick(languageCandidates(ctx)))
 		ctx.Next()
 	}
 }
 
+// translatorSelector 按语言偏好选择翻译器
+type translatorSelector struct {
+	matcher     language.Matcher
+	translators []ut.Translator // 与 matcher 的支持语言一一对应，首项为默认语言
+}
+
+// pick 按候选语言（支持 Accept-Language 格式）选择翻译器
+func (s *translatorSelector) pick(candidates []string) ut.Translator {
+	var tags []language.Tag
+	for _, c := range candidates {
+		parsed, _, err := language.ParseAcceptLanguage(c)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, parsed...)
+	}
+	if len(tags) == 0 {
+		return s.translators[0]
+	}
+	_, idx, conf := s.matcher.Match(tags...)
+	if conf == language.No {
+		return s.translators[0]
+	}
+	return s.translators[idx]
+}
+
 // Translator 从上下文获取翻译器
 func Translator(c *gin.Context) ut.Translator {
 	v, ok := c.Get(translatorContextKey)
@@ -0,0 +1,421 @@
+package abe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	ErrWebSocketClosed         = errors.New("websocket closed")           // 连接已关闭
+	ErrWebSocketSendBufferFull = errors.New("websocket send buffer full") // 发送缓冲已满
+)
+
+const (
+	defaultWSPingInterval = 30 * time.Second
+	defaultWSWriteTimeout = 10 * time.Second
+	defaultWSReadLimit    = 1 << 20
+	defaultWSSendBuffer   = 64
+)
+
+// WSOptions WebSocketHandler 选项
+type WSOptions struct {
+	// RequireAuth 为 true 时要求上下文中存在用户声明（需在认证中间件之后注册），否则返回 401
+	RequireAuth bool
+	// CheckOrigin 校验握手请求来源，nil 时仅允许与 Host 同源
+	CheckOrigin func(r *http.Request) bool
+	// Subprotocols 服务端支持的子协议
+	Subprotocols []string
+
+	// PingInterval 心跳间隔，默认 30s；超过两个间隔未收到任何消息（含 pong）视为断开；负数表示不发送心跳
+	PingInterval time.Duration
+	// WriteTimeout 单帧写超时，默认 10s
+	WriteTimeout time.Duration
+	// ReadLimit 单条入站消息的最大字节数，默认 1MB
+	ReadLimit int64
+	// SendBuffer 每个连接的发送缓冲条数，默认 64；缓冲写满（客户端过慢）时关闭该连接
+	SendBuffer int
+
+	// OnConnect 握手完成后调用，返回错误时以 1008（策略违规）关闭连接
+	OnConnect func(c *WSConn) error
+	// OnMessage 收到文本或二进制消息时调用，同一连接按到达顺序串行调用
+	OnMessage func(c *WSConn, messageType int, data []byte)
+	// OnClose 连接关闭且读写协程均已退出后调用，err 为导致关闭的读写错误（主动关闭时为 nil）
+	OnClose func(c *WSConn, err error)
+
+	// Topic 非空时订阅该事件总线主题并将每条消息的载荷推送给所有连接
+	// MessagePack 编码的载荷以二进制帧发送，其余以文本帧发送
+	Topic string
+	// TopicFilter 决定主题消息是否推送给某个连接（如按用户过滤），nil 表示推送给全部连接
+	TopicFilter func(c *WSConn, msg *EventMessage) bool
+}
+
+// WSConn 已建立的 WebSocket 连接
+// Send 系列方法可在任意协程调用，消息经发送缓冲由写协程依次写出
+type WSConn struct {
+	id        string
+	conn      *websocket.Conn
+	claims    UserTokenClaims
+	send      chan wsFrame
+	done      chan struct{}
+	wrote     chan struct{} // 写协程退出后关闭
+	closeOnce sync.Once
+	closeCode int
+	closeErr  error
+}
+
+type wsFrame struct {
+	messageType int
+	data        []byte
+}
+
+// ID 返回连接 ID（握手请求的请求 ID）
+func (c *WSConn) ID() string {
+	return c.id
+}
+
+// Claims 返回握手时上下文中的用户声明
+func (c *WSConn) Claims() (UserTokenClaims, bool) {
+	return c.claims, c.claims != nil
+}
+
+// Send 发送消息，messageType 为 websocket.TextMessage 或 websocket.BinaryMessage
+func (c *WSConn) Send(messageType int, data []byte) error {
+	select {
+	case <-c.done:
+		return ErrWebSocketClosed
+	default:
+	}
+	select {
+	case c.send <- wsFrame{messageType: messageType, data: data}:
+		return nil
+	case <-c.done:
+		return ErrWebSocketClosed
+	default:
+		return ErrWebSocketSendBufferFull
+	}
+}
+
+// SendJSON 将 v 编码为 JSON 并以文本帧发送
+func (c *WSConn) SendJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("编码 WebSocket 消息失败：%w", err)
+	}
+	return c.Send(websocket.TextMessage, data)
+}
+
+// Close 以 1000（正常关闭）关闭连接
+func (c *WSConn) Close() {
+	c.shutdown(websocket.CloseNormalClosure, nil)
+}
+
+// shutdown 标记连接关闭，写协程随后发送关闭帧并断开底层连接；仅首次调用生效
+func (c *WSConn) shutdown(code int, err error) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeErr = err
+		close(c.done)
+	})
+}
+
+// wsHub 记录 WebSocketHandler 的活动连接，负责主题推送与停机时关闭连接
+type wsHub struct {
+	engine  *Engine
+	opts    WSOptions
+	mu      sync.Mutex
+	conns   map[*WSConn]struct{}
+	closed  bool
+	active  sync.WaitGroup
+	subOnce sync.Once
+	cancel  context.CancelFunc
+}
+
+// WebSocketHandler 创建 WebSocket 处理器：升级连接并以协程池中的读写协程收发消息
+// 引擎停机时（OnShutdown 阶段）以 1001（离开）关闭全部连接并等待读写协程退出
+//
+// 使用示例:
+//
+//	rg.GET("/ws", abe.WebSocketHandler(e, abe.WSOptions{
+//	    RequireAuth: true,
+//	    Topic:       "notification.created",
+//	    TopicFilter: func(c *abe.WSConn, msg *abe.EventMessage) bool {
+//	        claims, _ := c.Claims()
+//	        return msg.Metadata("user_id") == claims.UserID()
+//	    },
+//	    OnMessage: func(c *abe.WSConn, _ int, data []byte) {
+//	        _ = c.Send(websocket.TextMessage, data) // 回显
+//	    },
+//	}))
+func WebSocketHandler(e *Engine, opts WSOptions) gin.HandlerFunc {
+	if opts.PingInterval == 0 {
+		opts.PingInterval = defaultWSPingInterval
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = defaultWSWriteTimeout
+	}
+	if opts.ReadLimit <= 0 {
+		opts.ReadLimit = defaultWSReadLimit
+	}
+	if opts.SendBuffer <= 0 {
+		opts.SendBuffer = defaultWSSendBuffer
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin:  opts.CheckOrigin,
+		Subprotocols: opts.Subprotocols,
+	}
+	hub := &wsHub{engine: e, opts: opts, conns: make(map[*WSConn]struct{})}
+	e.OnShutdown(hub.close)
+
+	return func(ctx *gin.Context) {
+		claims, _ := GetUserTokenClaims(ctx)
+		if opts.RequireAuth && claims == nil {
+			_ = ctx.Error(fmt.Errorf("WebSocket 连接需要认证：%w", ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		// 升级失败时 Upgrader 已写出错误响应
+		conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+		if err != nil {
+			e.logger.Debug("WebSocket 升级失败", "request_id", GetRequestID(ctx), "error", err)
+			return
+		}
+
+		id := GetRequestID(ctx)
+		if id == "" {
+			id = uuid.New().String()
+		}
+		c := &WSConn{
+			id:     id,
+			conn:   conn,
+			claims: claims,
+			send:   make(chan wsFrame, opts.SendBuffer),
+			done:   make(chan struct{}),
+			wrote:  make(chan struct{}),
+		}
+
+		if opts.OnConnect != nil {
+			if err := opts.OnConnect(c); err != nil {
+				closeWS(conn, websocket.ClosePolicyViolation, err.Error(), opts.WriteTimeout)
+				return
+			}
+		}
+		if !hub.add(c) {
+			closeWS(conn, websocket.CloseGoingAway, "server shutting down", opts.WriteTimeout)
+			return
+		}
+		hub.subscribe()
+
+		if err := e.SubmitNamed("websocket.writer", func() { hub.writeLoop(c) }, "conn_id", c.id); err != nil {
+			hub.remove(c)
+			closeWS(conn, websocket.CloseTryAgainLater, "server busy", opts.WriteTimeout)
+			return
+		}
+		if err := e.SubmitNamed("websocket.reader", func() { hub.readLoop(c) }, "conn_id", c.id); err != nil {
+			c.shutdown(websocket.CloseTryAgainLater, err)
+			<-c.wrote
+			hub.remove(c)
+			if opts.OnClose != nil {
+				opts.OnClose(c, err)
+			}
+		}
+	}
+}
+
+// closeWS 发送关闭帧并断开底层连接
+func closeWS(conn *websocket.Conn, code int, reason string, timeout time.Duration) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(timeout))
+	_ = conn.Close()
+}
+
+// writeLoop 写协程：依次写出发送缓冲中的消息并定时发送 ping；连接关闭时写出关闭帧并断开底层连接
+func (h *wsHub) writeLoop(c *WSConn) {
+	defer close(c.wrote)
+
+	var ping <-chan time.Time
+	if h.opts.PingInterval > 0 {
+		ticker := time.NewTicker(h.opts.PingInterval)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+
+	for {
+		select {
+		case <-c.done:
+			closeWS(c.conn, c.closeCode, "", h.opts.WriteTimeout)
+			return
+		case f := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(h.opts.WriteTimeout))
+			if err := c.conn.WriteMessage(f.messageType, f.data); err != nil {
+				c.shutdown(websocket.CloseAbnormalClosure, err)
+				_ = c.conn.Close()
+				return
+			}
+		case <-ping:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.opts.WriteTimeout)); err != nil {
+				c.shutdown(websocket.CloseAbnormalClosure, err)
+				_ = c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// readLoop 读协程：读取入站消息并调用 OnMessage；退出时等待写协程结束，再移除连接并调用 OnClose
+func (h *wsHub) readLoop(c *WSConn) {
+	defer func() {
+		<-c.wrote
+		h.remove(c)
+		if h.opts.OnClose != nil {
+			h.opts.OnClose(c, c.closeErr)
+		}
+	}()
+
+	c.conn.SetReadLimit(h.opts.ReadLimit)
+	if h.opts.PingInterval > 0 {
+		wait := 2 * h.opts.PingInterval
+		_ = c.conn.SetReadDeadline(time.Now().Add(wait))
+		c.conn.SetPongHandler(func(string) error {
+			return c.conn.SetReadDeadline(time.Now().Add(wait))
+		})
+	}
+
+	for {
+		mt, data, err := c.conn.ReadMessage()
+		if err != nil {
+			// 客户端正常关闭或服务端已发起关闭时不视为错误
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				err = nil
+			}
+			c.shutdown(websocket.CloseNormalClosure, err)
+			return
+		}
+		if h.opts.PingInterval > 0 {
+			_ = c.conn.SetReadDeadline(time.Now().Add(2 * h.opts.PingInterval))
+		}
+		if h.opts.OnMessage != nil {
+			h.opts.OnMessage(c, mt, data)
+		}
+	}
+}
+
+// add 登记连接，停机后返回 false
+func (h *wsHub) add(c *WSConn) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.conns[c] = struct{}{}
+	h.active.Add(1)
+	return true
+}
+
+// remove 移除连接（可重复调用）
+func (h *wsHub) remove(c *WSConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.conns[c]; ok {
+		delete(h.conns, c)
+		h.active.Done()
+	}
+}
+
+// subscribe 首个连接建立时订阅推送主题，整个处理器共用一个订阅
+func (h *wsHub) subscribe() {
+	if h.opts.Topic == "" {
+		return
+	}
+	h.subOnce.Do(func() {
+		bus := h.engine.EventBus()
+		if bus == nil {
+			h.engine.logger.Warn("事件总线未初始化，WebSocket 主题推送不可用", "topic", h.opts.Topic)
+			return
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		ch, err := bus.Subscribe(ctx, h.opts.Topic)
+		if err != nil {
+			cancel()
+			h.engine.logger.Error("订阅 WebSocket 推送主题失败", "topic", h.opts.Topic, "error", err)
+			return
+		}
+		h.mu.Lock()
+		h.cancel = cancel
+		h.mu.Unlock()
+
+		err = h.engine.SubmitNamed("websocket.broadcast", func() {
+			for msg := range ch {
+				h.broadcast(msg)
+				msg.Ack()
+			}
+		}, "topic", h.opts.Topic)
+		if err != nil {
+			h.engine.logger.Error("启动 WebSocket 主题推送失败", "topic", h.opts.Topic, "error", err)
+			cancel()
+		}
+	})
+}
+
+// broadcast 将主题消息推送给匹配的连接，发送缓冲已满的连接视为过慢并关闭
+func (h *wsHub) broadcast(msg *EventMessage) {
+	mt := websocket.TextMessage
+	if msg.Metadata(EventContentTypeMetadataKey) == "application/msgpack" {
+		mt = websocket.BinaryMessage
+	}
+
+	h.mu.Lock()
+	conns := make([]*WSConn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range conns {
+		if h.opts.TopicFilter != nil && !h.opts.TopicFilter(c, msg) {
+			continue
+		}
+		if err := c.Send(mt, msg.Payload()); errors.Is(err, ErrWebSocketSendBufferFull) {
+			c.shutdown(websocket.ClosePolicyViolation, err)
+		}
+	}
+}
+
+// close 停机时取消主题订阅，以 1001 关闭全部连接并等待读写协程退出
+func (h *wsHub) close(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	if h.cancel != nil {
+		h.cancel()
+	}
+	conns := make([]*WSConn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range conns {
+		c.shutdown(websocket.CloseGoingAway, nil)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.active.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("等待 WebSocket 连接关闭超时（共 %d 个连接）：%w", len(conns), ctx.Err())
+	}
+}
@@ -32,23 +32,24 @@ func NewEngine() *Engine {
 	wire.Build(
 		wire.Struct(
 			new(Engine),
-			"config", "router", "db", "cron", "events", "pool", "logger", "enforcer", "validator", "middlewareManager",
+			"config", "router", "db", "cron", "events", "pool", "logger", "logLevel", "enforcer", "policyWatcher", "validator", "middlewareManager",
 			"i18nBundle", "rootScope",
 		),
 		newCron,
 		newConfig,
 		newLogger,
+		newLogLevel,
 		newDB,
 		newRouter,
-		newGoChannelBus,
+		newEventBus,
 		newGoChannelConfig,
 		newGoChannelLogger,
-		newEnforcer,
+		newEnforcerWithWatcher,
+		newPolicyWatcher,
 		newPool,
 		newValidator,
 		newMiddlewareManager,
 		newI18nBundle,
-		wire.Bind(new(EventBus), new(*goChannelBus)),
 		newRootScope,
 	)
 	return nil
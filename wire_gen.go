@@ -31,15 +31,17 @@ package abe
 //	}
 func NewEngine() *Engine {
 	viper := newConfig()
-	logger := newLogger(viper)
+	levelVar := newLogLevel(viper)
+	logger := newLogger(viper, levelVar)
 	engine := newRouter(viper, logger)
 	db := newDB(viper)
-	cron := newCron(logger)
+	cron := newCron(logger, viper)
 	config := newGoChannelConfig(viper)
 	loggerAdapter := newGoChannelLogger(logger)
-	abeGoChannelBus := newGoChannelBus(config, loggerAdapter)
+	eventBus := newEventBus(viper, config, loggerAdapter)
 	pool := newPool(viper, logger)
-	enforcer := newEnforcer(db, logger, viper)
+	watcher := newPolicyWatcher(viper, eventBus, logger)
+	enforcer := newEnforcerWithWatcher(db, logger, viper, watcher)
 	validator := newValidator(viper)
 	middlewareManager := newMiddlewareManager()
 	bundle := newI18nBundle(viper, logger)
@@ -49,10 +51,12 @@ func NewEngine() *Engine {
 		router:            engine,
 		db:                db,
 		cron:              cron,
-		events:            abeGoChannelBus,
+		events:            eventBus,
 		pool:              pool,
 		logger:            logger,
+		logLevel:          levelVar,
 		enforcer:          enforcer,
+		policyWatcher:     watcher,
 		validator:         validator,
 		middlewareManager: middlewareManager,
 		i18nBundle:        bundle,